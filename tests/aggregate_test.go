@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestAppend(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	third := errors.New("third")
+	var aggregate error
+	for _, err := range []error{first, nil, second, third, nil} {
+		aggregate = wrapperrors.Append(aggregate, err)
+	}
+	assert.Equal(t, "aggregate_error", wrapperrors.Code(aggregate))
+	assert.Len(t, aggregate.(interface{ Unwrap() []error }).Unwrap(), 3)
+	assert.True(t, errors.Is(aggregate, first))
+	assert.True(t, errors.Is(aggregate, second))
+	assert.True(t, errors.Is(aggregate, third))
+}
+
+func TestAppend_ExistingWrapper(t *testing.T) {
+	base := wrapperrors.New("validation_error", errors.New("first"))
+	aggregate := wrapperrors.Append(base, errors.New("second"), nil, errors.New("third"))
+	assert.Equal(t, "aggregate_error", wrapperrors.Code(aggregate))
+	assert.Len(t, aggregate.(interface{ Unwrap() []error }).Unwrap(), 3)
+	assert.Equal(t, 3, wrapperrors.Count(aggregate))
+	assert.Equal(t, base, wrapperrors.Errors(aggregate)[0])
+
+	assert.Equal(t, 2, wrapperrors.Count(wrapperrors.Append(wrapperrors.New("a", nil), wrapperrors.New("b", nil))))
+
+	existing := wrapperrors.New("validation_error", nil).WithCauses(errors.New("first"), errors.New("second"))
+	aggregate = wrapperrors.Append(existing, errors.New("third"))
+	assert.Equal(t, "validation_error", wrapperrors.Code(aggregate))
+	assert.Equal(t, 3, wrapperrors.Count(aggregate))
+}
+
+func TestAppend_Nil(t *testing.T) {
+	assert.Nil(t, wrapperrors.Append(nil))
+	assert.Nil(t, wrapperrors.Append(nil, nil, nil))
+	var err error = wrapperrors.Append(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, wrapperrors.Count(wrapperrors.Append(nil, nil, errors.New("first"))))
+}
+
+func TestAppend_InPlace(t *testing.T) {
+	aggregate := wrapperrors.Append(nil, errors.New("first"), errors.New("second"))
+	appended := wrapperrors.Append(aggregate, errors.New("third"))
+	assert.Same(t, aggregate, appended)
+	assert.Equal(t, 3, wrapperrors.Count(aggregate))
+}
+
+func TestAppend_PlainError(t *testing.T) {
+	aggregate := wrapperrors.Append(errors.New("first"), errors.New("second"))
+	assert.Equal(t, "aggregate_error", wrapperrors.Code(aggregate))
+	assert.Len(t, aggregate.(interface{ Unwrap() []error }).Unwrap(), 2)
+}
+
+func TestAppend_MergesFields(t *testing.T) {
+	first := wrapperrors.New("not_found", nil).WithField("id", "abc").WithField("request_id", "req-1")
+	second := wrapperrors.New("not_found", nil).WithField("id", "xyz").WithField("request_id", "req-1")
+	third := wrapperrors.New("conflict", nil).WithField("id", "qwe").WithField("table", "car")
+	aggregate := wrapperrors.Append(nil, first, second, third)
+	assert.Equal(t, map[string]interface{}{
+		"id":         []interface{}{"abc", "xyz", "qwe"},
+		"request_id": "req-1",
+		"table":      "car",
+	}, wrapperrors.Fields(aggregate))
+
+	body, err := json.Marshal(aggregate.Json())
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"id":["abc","xyz","qwe"]`)
+}
+
+func TestErrors_Single(t *testing.T) {
+	single := wrapperrors.New("not_found", errors.New("missing"))
+	assert.Equal(t, []error{single}, wrapperrors.Errors(single))
+	assert.Equal(t, 1, wrapperrors.Count(single))
+}
+
+func TestErrors_Aggregate(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	third := errors.New("third")
+	nested := wrapperrors.Append(nil, second, third)
+	aggregate := wrapperrors.Append(nil, first, nested)
+	assert.Equal(t, []error{first, second, third}, wrapperrors.Errors(aggregate))
+	assert.Equal(t, 3, wrapperrors.Count(aggregate))
+}
+
+func TestErrors_NonWrapper(t *testing.T) {
+	plain := errors.New("plain")
+	assert.Equal(t, []error{plain}, wrapperrors.Errors(plain))
+	assert.Equal(t, 1, wrapperrors.Count(plain))
+	assert.Equal(t, 0, wrapperrors.Count(nil))
+}
+
+func TestResolveStatus(t *testing.T) {
+	notFound := wrapperrors.New("not_found", nil).WithStatus(http.StatusNotFound)
+	internal := wrapperrors.New("internal", nil).WithStatus(http.StatusInternalServerError)
+	assert.Equal(t, http.StatusInternalServerError, wrapperrors.ResolveStatus(wrapperrors.Append(nil, notFound, internal)))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.ResolveStatus(notFound))
+}
+
+func TestResolveStatus_Precedence(t *testing.T) {
+	badRequest := wrapperrors.New("invalid_payload", nil).WithStatus(http.StatusBadRequest)
+	conflict := wrapperrors.New("conflict", nil).WithStatus(http.StatusConflict)
+	aggregate := wrapperrors.Append(nil, badRequest, conflict)
+	assert.Equal(t, http.StatusBadRequest, wrapperrors.ResolveStatus(aggregate))
+
+	wrapperrors.SetStatusPrecedence(http.StatusConflict)
+	defer wrapperrors.SetStatusPrecedence()
+	assert.Equal(t, http.StatusConflict, wrapperrors.ResolveStatus(aggregate))
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestBatchResult(t *testing.T) {
+	notFound := wrapperrors.Define("batch_not_found", http.StatusNotFound)
+	var result wrapperrors.BatchResult
+	result.AddSuccess(0)
+	result.AddError(2, notFound.FromDefinition(nil).WithPublicMessage("car has not been found"))
+	result.AddSuccess(1)
+	assert.Equal(t, http.StatusMultiStatus, result.Status())
+
+	data, err := json.Marshal(&result)
+	assert.NoError(t, err)
+	var items []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &items))
+	assert.Len(t, items, 3)
+	assert.Equal(t, map[string]interface{}{"index": 0.0, "status": 200.0}, items[0])
+	assert.Equal(t, map[string]interface{}{"index": 1.0, "status": 200.0}, items[1])
+	assert.Equal(t, 2.0, items[2]["index"])
+	assert.Equal(t, 404.0, items[2]["status"])
+	itemError := items[2]["error"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"batch_not_found"}, itemError["code"])
+	assert.Equal(t, []interface{}{"car has not been found"}, itemError["message"])
+}
+
+func TestBatchResult_Status(t *testing.T) {
+	var result wrapperrors.BatchResult
+	assert.Equal(t, http.StatusOK, result.Status())
+	result.AddSuccess(0)
+	assert.Equal(t, http.StatusOK, result.Status())
+
+	var failed wrapperrors.BatchResult
+	failed.AddError(0, wrapperrors.New("batch_conflict", nil).WithStatus(http.StatusConflict))
+	assert.Equal(t, http.StatusConflict, failed.Status())
+	failed.AddError(1, errors.New("boom"))
+	assert.Equal(t, http.StatusInternalServerError, failed.Status())
+}
+
+func TestBatchResult_StatusAllFailed(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		var result wrapperrors.BatchResult
+		result.AddError(2, wrapperrors.New("batch_bad_request", nil).WithStatus(http.StatusBadRequest))
+		result.AddError(0, wrapperrors.New("batch_not_found", nil).WithStatus(http.StatusNotFound))
+		result.AddError(1, wrapperrors.New("batch_conflict", nil).WithStatus(http.StatusConflict))
+		assert.Equal(t, http.StatusNotFound, result.Status())
+	}
+}
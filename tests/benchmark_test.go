@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"net/http"
+	"testing"
+)
+
+func BenchmarkError_SingleCode(b *testing.B) {
+	err := wrapperrors.New("not_found", nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkError_QuotedSingleCode(b *testing.B) {
+	err := wrapperrors.New("not_found, gone", nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkError_Full(b *testing.B) {
+	err := wrapperrors.New("not_found", errors.New("missing")).
+		WithMessage("car has not been found").
+		WithStatus(http.StatusNotFound)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = err.Error()
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = wrapperrors.New("not_found", nil)
+	}
+}
+
+func BenchmarkNew_Pooled(b *testing.B) {
+	wrapperrors.SetPooling(true)
+	defer wrapperrors.SetPooling(false)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		wrapperrors.Release(wrapperrors.New("not_found", nil))
+	}
+}
+
+func BenchmarkPeek(b *testing.B) {
+	err := wrapperrors.New("not_found", errors.New("missing")).
+		WithCode("car").
+		WithStatus(http.StatusNotFound)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = wrapperrors.Peek(err)
+	}
+}
+
+func BenchmarkIs_ManyDefinitions(b *testing.B) {
+	definitions := make([]wrapperrors.ErrorWrapper, 500)
+	for i := range definitions {
+		definitions[i] = wrapperrors.Define(fmt.Sprintf("benchmark_definition_%d", i), http.StatusBadRequest)
+	}
+	err := definitions[len(definitions)-1].FromDefinition(errors.New("missing"))
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, definition := range definitions {
+				if err.String() == definition.String() {
+					break
+				}
+			}
+		}
+	})
+	b.Run("Is", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, definition := range definitions {
+				if wrapperrors.Is(err, definition) {
+					break
+				}
+			}
+		}
+	})
+}
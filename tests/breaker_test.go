@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldTripBreaker_Propagation(t *testing.T) {
+	inner := wrapperrors.New("upstream_unavailable", nil).WithBreakerTrip(true)
+	outer := wrapperrors.New("internal", fmt.Errorf("calling upstream: %w", inner))
+	assert.True(t, wrapperrors.ShouldTripBreaker(inner))
+	assert.True(t, wrapperrors.ShouldTripBreaker(outer))
+	assert.True(t, wrapperrors.ShouldTripBreaker(wrapperrors.Wrap(outer, "wrapped")))
+}
+
+func TestShouldTripBreaker_Override(t *testing.T) {
+	inner := wrapperrors.New("upstream_unavailable", nil).WithBreakerTrip(true)
+	outer := wrapperrors.New("invalid_payload", inner).WithBreakerTrip(false)
+	assert.False(t, wrapperrors.ShouldTripBreaker(outer))
+	assert.False(t, wrapperrors.ShouldTripBreaker(wrapperrors.New("internal", nil)))
+	assert.False(t, wrapperrors.ShouldTripBreaker(errors.New("plain")))
+}
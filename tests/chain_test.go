@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestHasCode(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows)
+	outer := wrapperrors.New("internal", inner)
+	assert.True(t, wrapperrors.HasCode(outer, "internal"))
+	assert.False(t, wrapperrors.HasCode(outer, "not_found"))
+	assert.False(t, wrapperrors.HasCode(sql.ErrNoRows, "not_found"))
+}
+
+func TestChainHasCode(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows)
+	middle := fmt.Errorf("lookup failed: %w", inner)
+	outer := wrapperrors.New("internal", middle)
+	assert.True(t, wrapperrors.ChainHasCode(outer, "internal"))
+	assert.True(t, wrapperrors.ChainHasCode(outer, "not_found"))
+	assert.False(t, wrapperrors.ChainHasCode(outer, "invalid_payload"))
+	assert.False(t, wrapperrors.ChainHasCode(sql.ErrNoRows, "not_found"))
+}
+
+func TestChainHasCode_Cyclic(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", first)
+	first.WithCause(second)
+	assert.True(t, wrapperrors.ChainHasCode(first, "second"))
+	assert.False(t, wrapperrors.ChainHasCode(first, "third"))
+}
+
+func TestAllFields(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows).
+		WithField("table", "car").
+		WithField("id", "inner")
+	outer := wrapperrors.New("internal", inner).
+		WithField("id", "outer").
+		WithField("handler", "GetCar")
+	expected := map[string]interface{}{
+		"table":   "car",
+		"id":      "outer",
+		"handler": "GetCar",
+	}
+	assert.Equal(t, expected, wrapperrors.AllFields(outer))
+	assert.Equal(t, map[string]interface{}{}, wrapperrors.AllFields(sql.ErrNoRows))
+}
+
+func TestFlatten(t *testing.T) {
+	root := errors.New("connection refused")
+	level4 := wrapperrors.New("db_error", root).WithMessage("query failed").WithStatus(http.StatusServiceUnavailable)
+	level3 := wrapperrors.New("not_found", level4).WithMessage("car lookup failed").WithStatus(http.StatusNotFound)
+	level2 := fmt.Errorf("repository: %w", level3)
+	level1 := wrapperrors.New("not_found", level2).WithMessage("get car failed").WithField("id", "abc")
+
+	flat := wrapperrors.Flatten(level1)
+	assert.Equal(t, "not_found; db_error", wrapperrors.Code(flat))
+	assert.Equal(t, "get car failed; car lookup failed; query failed", wrapperrors.Message(flat))
+	assert.Equal(t, "[{\"message\": \"Not Found\", \"code\": 404}, {\"message\": \"Service Unavailable\", \"code\": 503}]", wrapperrors.Status(flat))
+	assert.Equal(t, root, wrapperrors.Cause(flat))
+	assert.Equal(t, "abc", wrapperrors.Fields(flat)["id"])
+}
+
+func TestFlatten_Cyclic(t *testing.T) {
+	first := wrapperrors.New("first", nil).WithMessage("first message")
+	second := wrapperrors.New("second", first).WithMessage("second message")
+	first.WithCause(second)
+
+	flat := wrapperrors.Flatten(first)
+	assert.Equal(t, "first; second", wrapperrors.Code(flat))
+	assert.Equal(t, "first message; second message", wrapperrors.Message(flat))
+	assert.Nil(t, wrapperrors.Cause(flat))
+}
+
+func TestChain(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows)
+	outer := wrapperrors.New("internal", inner)
+	var chain []error
+	for err := range wrapperrors.Chain(outer) {
+		chain = append(chain, err)
+	}
+	assert.Equal(t, []error{outer, inner, sql.ErrNoRows}, chain)
+}
+
+func TestChain_Cycle(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", first)
+	first.WithCause(second)
+	count := 0
+	for range wrapperrors.Chain(first) {
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestWalk(t *testing.T) {
+	first := wrapperrors.New("not_found", sql.ErrNoRows)
+	second := wrapperrors.New("invalid_payload", errors.New("missing name"))
+	aggregate := wrapperrors.Append(nil, first, second)
+	var visited []string
+	wrapperrors.Walk(aggregate, func(depth int, err error) bool {
+		visited = append(visited, fmt.Sprintf("%d:%s", depth, codeOrText(err)))
+		return true
+	})
+	assert.Equal(t, []string{
+		"0:aggregate_error",
+		"1:not_found",
+		"2:sql: no rows in result set",
+		"1:invalid_payload",
+		"2:missing name",
+	}, visited)
+
+	visited = nil
+	wrapperrors.Walk(aggregate, func(depth int, err error) bool {
+		visited = append(visited, fmt.Sprintf("%d:%s", depth, codeOrText(err)))
+		return !wrapperrors.HasCode(err, "not_found")
+	})
+	assert.Equal(t, []string{"0:aggregate_error", "1:not_found", "1:invalid_payload", "2:missing name"}, visited)
+}
+
+func TestWalk_Cycle(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", first)
+	first.WithCause(second)
+	var depths []int
+	wrapperrors.Walk(first, func(depth int, err error) bool {
+		depths = append(depths, depth)
+		return true
+	})
+	assert.Equal(t, []int{0, 1}, depths)
+}
+
+func codeOrText(err error) string {
+	if code := wrapperrors.Code(err); code != "" {
+		return code
+	}
+	return err.Error()
+}
+
+func TestCauseAs(t *testing.T) {
+	_, openErr := os.Open("/nonexistent/car.json")
+	err := wrapperrors.Wrap(wrapperrors.New("read_failed", nil).WithCauses(errors.New("first"), openErr), "loading car")
+	pathErr, ok := wrapperrors.CauseAs[*os.PathError](err)
+	assert.True(t, ok)
+	assert.Equal(t, "/nonexistent/car.json", pathErr.Path)
+
+	_, ok = wrapperrors.CauseAs[*os.PathError](wrapperrors.New("not_found", sql.ErrNoRows))
+	assert.False(t, ok)
+	_, ok = wrapperrors.CauseAs[*os.PathError](openErr)
+	assert.False(t, ok)
+}
+
+type sliceError []error
+
+func (e sliceError) Error() string   { return "slice error" }
+func (e sliceError) Unwrap() []error { return e }
+
+func TestCauseAs_UncomparableError(t *testing.T) {
+	_, openErr := os.Open("/nonexistent/car.json")
+	pathErr, ok := wrapperrors.CauseAs[*os.PathError](sliceError{errors.New("first"), openErr})
+	assert.True(t, ok)
+	assert.Equal(t, "/nonexistent/car.json", pathErr.Path)
+
+	_, ok = wrapperrors.CauseAs[sliceError](sliceError{errors.New("first")})
+	assert.False(t, ok)
+}
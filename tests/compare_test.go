@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestComparer_Equal(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	got := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	want := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	assert.Empty(t, cmp.Diff(got, want, wrapperrors.Comparer()))
+}
+
+func TestComparer_Different(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	got := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	want := notFound.FromDefinition(sql.ErrNoRows).WithMessage("person has not been found")
+	assert.NotEmpty(t, cmp.Diff(got, want, wrapperrors.Comparer()))
+	assert.NotEmpty(t, cmp.Diff(got, notFound.FromDefinition(sql.ErrConnDone), wrapperrors.Comparer()))
+}
+
+func TestEqual(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	base := func() wrapperrors.ErrorWrapper {
+		return notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	}
+	assert.True(t, base().Equal(base()))
+	assert.True(t, base().WithField("id", 1).Equal(base()))
+	assert.False(t, base().Equal(base().WithCode("car")))
+	assert.False(t, base().Equal(base().WithMessage("again")))
+	assert.False(t, base().Equal(base().WithStatus(http.StatusGone)))
+	assert.False(t, base().Equal(notFound.FromDefinition(sql.ErrConnDone).WithMessage("car has not been found")))
+	assert.False(t, base().Equal(nil))
+}
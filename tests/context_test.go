@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"context"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type traceIDKey struct{}
+
+type userKey struct{}
+
+func init() {
+	wrapperrors.RegisterContextExtractor(func(ctx context.Context) map[string]interface{} {
+		fields := make(map[string]interface{})
+		if traceID, ok := ctx.Value(traceIDKey{}).(string); ok {
+			fields["trace_id"] = traceID
+			fields["origin"] = "trace"
+		}
+		return fields
+	})
+	wrapperrors.RegisterContextExtractor(func(ctx context.Context) map[string]interface{} {
+		fields := make(map[string]interface{})
+		if user, ok := ctx.Value(userKey{}).(string); ok {
+			fields["user"] = user
+			fields["origin"] = "user"
+		}
+		return fields
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "abc-123")
+	ctx = context.WithValue(ctx, userKey{}, "felipe")
+	err := wrapperrors.FromContext(ctx, "internal")
+	fields := wrapperrors.Fields(err)
+	assert.Equal(t, "abc-123", fields["trace_id"])
+	assert.Equal(t, "felipe", fields["user"])
+	assert.Equal(t, "user", fields["origin"])
+	assert.Equal(t, "{\"code\": [\"internal\"], \"fields\": {\"origin\":\"user\",\"trace_id\":\"abc-123\",\"user\":\"felipe\"}}", err.String())
+}
+
+func TestFromContext_NoValues(t *testing.T) {
+	err := wrapperrors.FromContext(context.Background(), "internal")
+	assert.Empty(t, wrapperrors.Fields(err))
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWrap_DeadlineExceeded(t *testing.T) {
+	err := wrapperrors.Wrap(fmt.Errorf("querying car: %w", context.DeadlineExceeded), "car lookup")
+	assert.Equal(t, http.StatusGatewayTimeout, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "exceeded", wrapperrors.Fields(err)["deadline_reason"])
+
+	wrapped := wrapperrors.Wrap(err, "handler")
+	assert.Equal(t, http.StatusGatewayTimeout, wrapperrors.GetStatusCode(wrapped))
+	assert.Len(t, wrapped.Json()["status"], 2)
+}
+
+func TestNew_Canceled(t *testing.T) {
+	err := wrapperrors.New("car_lookup", context.Canceled)
+	assert.Equal(t, 499, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "canceled", wrapperrors.Fields(err)["deadline_reason"])
+
+	assert.Equal(t, http.StatusInternalServerError, wrapperrors.GetStatusCode(wrapperrors.Wrap(errors.New("boom"), "car lookup")))
+}
+
+func TestWithDeadline(t *testing.T) {
+	deadline := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := wrapperrors.New("car_lookup", context.DeadlineExceeded).WithDeadline(deadline)
+	assert.Equal(t, deadline, wrapperrors.Fields(err)["deadline"])
+	assert.Equal(t, "exceeded", wrapperrors.Fields(err)["deadline_reason"])
+	assert.Equal(t, http.StatusGatewayTimeout, wrapperrors.GetStatusCode(err))
+}
+
+func TestWithCause_DeadlineExceeded(t *testing.T) {
+	err := wrapperrors.New("car_lookup", nil).WithCause(context.DeadlineExceeded)
+	assert.Equal(t, http.StatusGatewayTimeout, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "exceeded", wrapperrors.Fields(err)["deadline_reason"])
+
+	err = wrapperrors.InternalError.WithCauses(errors.New("boom"), fmt.Errorf("closing: %w", context.Canceled))
+	assert.Equal(t, 499, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "canceled", wrapperrors.Fields(err)["deadline_reason"])
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithQuery(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows).
+		WithQuery("SELECT id, name FROM car WHERE id = ?", "abc", 3)
+	debug := wrappedError.Debug()
+	assert.Contains(t, debug, "query: SELECT id, name FROM car WHERE id = ?")
+	assert.Contains(t, debug, "args: [abc 3]")
+
+	body, err := json.Marshal(wrappedError.Json())
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "SELECT")
+	assert.NotContains(t, wrappedError.String(), "SELECT")
+	assert.NotContains(t, wrappedError.Error(), "SELECT")
+}
+
+func TestDebug_WithoutQuery(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows)
+	assert.True(t, strings.HasPrefix(wrappedError.Debug(), wrappedError.String()+"\n"))
+	assert.NotContains(t, wrappedError.Debug(), "query:")
+}
+
+func TestDebug_Process(t *testing.T) {
+	hostname, err := os.Hostname()
+	assert.NoError(t, err)
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows)
+	debug := wrappedError.Debug()
+	assert.Contains(t, debug, fmt.Sprintf("pid: %d", os.Getpid()))
+	assert.Contains(t, debug, "hostname: "+hostname)
+	assert.Contains(t, debug, "tests.TestDebug_Process")
+}
+
+func TestSetDebugExtras(t *testing.T) {
+	wrapperrors.SetDebugExtras(func() map[string]string {
+		return map[string]string{"version": "1.2.3"}
+	})
+	defer wrapperrors.SetDebugExtras(nil)
+	assert.Contains(t, wrapperrors.New("not_found", nil).Debug(), "version: 1.2.3")
+}
+
+func TestNewDetailed(t *testing.T) {
+	wrappedError := wrapperrors.NewDetailed("not_found", "car has not been found", "car abc missing from table car", sql.ErrNoRows)
+	assert.Equal(t, "car has not been found", wrappedError.PublicError())
+	assert.NotContains(t, wrappedError.PublicError(), "table car")
+	assert.Contains(t, wrappedError.Debug(), "car has not been found")
+	assert.Contains(t, wrappedError.Debug(), "car abc missing from table car")
+	assert.Contains(t, wrappedError.Debug(), "sql: no rows in result set")
+}
+
+func TestPublicError_Default(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows).WithMessage("internal detail")
+	assert.Equal(t, "An error occurred.", wrappedError.PublicError())
+}
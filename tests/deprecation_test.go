@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSetDeprecationWarnings(t *testing.T) {
+	buff := bytes.Buffer{}
+	log.SetOutput(&buff)
+	defer log.SetOutput(os.Stderr)
+
+	wrapperrors.New("not_found", nil)
+	assert.Empty(t, buff.String())
+
+	wrapperrors.SetDeprecationWarnings(true)
+	defer wrapperrors.SetDeprecationWarnings(false)
+	for i := 0; i < 3; i++ {
+		wrapperrors.New("not_found", nil)
+	}
+	wrapperrors.New("not_found", nil)
+	for i := 0; i < 3; i++ {
+		wrapperrors.Wrap(sql.ErrNoRows, "car has not been found")
+		wrapperrors.Wrapf(sql.ErrNoRows, "car %d has not been found", i)
+	}
+
+	output := buff.String()
+	assert.Equal(t, 2, strings.Count(output, "New is deprecated, use Define and FromDefinition instead"))
+	assert.Equal(t, 1, strings.Count(output, "Wrap is deprecated, use Wrapf instead"))
+	assert.Contains(t, output, "deprecation_test.go")
+}
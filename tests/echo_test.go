@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors/echoerrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestToEchoError(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	err := notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car abc missing from table car").
+		WithPublicMessage("car has not been found")
+	httpError := echoerrors.ToEchoError(fmt.Errorf("handler: %w", err))
+	assert.Equal(t, http.StatusNotFound, httpError.Code)
+	assert.Equal(t, wrapperrors.ResponseBody(err), httpError.Message)
+	assert.Equal(t, []string{"car has not been found"}, httpError.Message.(map[string]interface{})["message"])
+	assert.True(t, errors.Is(httpError.Internal, sql.ErrNoRows))
+	assert.NotContains(t, httpError.Message, "cause")
+}
+
+func TestToEchoError_PlainError(t *testing.T) {
+	httpError := echoerrors.ToEchoError(errors.New("boom"))
+	assert.Equal(t, http.StatusInternalServerError, httpError.Code)
+	assert.Equal(t, []string{"unknown_error"}, httpError.Message.(map[string]interface{})["code"])
+}
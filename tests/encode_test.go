@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEncodeJSON(t *testing.T) {
+	err := wrapperrors.New("not_found", errors.New("quoted \"cause\"")).
+		WithMessage("car abc missing from table car").
+		WithPublicMessage("car has not been found").
+		WithStatus(http.StatusNotFound).
+		WithField("id", "abc")
+	buff := bytes.Buffer{}
+	assert.NoError(t, err.EncodeJSON(&buff))
+
+	expected, marshalErr := json.Marshal(wrapperrors.ResponseBody(err))
+	assert.NoError(t, marshalErr)
+	assert.JSONEq(t, string(expected), buff.String())
+	assert.NotContains(t, buff.String(), "table car")
+	assert.NotContains(t, buff.String(), "cause")
+	assert.NotContains(t, buff.String(), "fields")
+}
+
+func TestEncodeJSON_LargeAggregate(t *testing.T) {
+	wrapperrors.SetExposeInternals(true)
+	defer wrapperrors.SetExposeInternals(false)
+	var aggregate wrapperrors.ErrorWrapper
+	for i := 0; i < 1000; i++ {
+		aggregate = wrapperrors.Append(aggregate, fmt.Errorf("row %d failed", i))
+	}
+	buff := bytes.Buffer{}
+	assert.NoError(t, aggregate.EncodeJSON(&buff))
+
+	decoded := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(buff.Bytes(), &decoded))
+	assert.Equal(t, []interface{}{"aggregate_error"}, decoded["code"])
+	causes := strings.Split(decoded["cause"].(string), "; ")
+	assert.Len(t, causes, 1000)
+	assert.Equal(t, "row 999 failed", causes[999])
+}
+
+func TestEncodeJSON_Empty(t *testing.T) {
+	buff := bytes.Buffer{}
+	assert.NoError(t, wrapperrors.Flatten(nil).EncodeJSON(&buff))
+	assert.JSONEq(t, `{"message":["An error occurred."]}`, buff.String())
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestSetGlobalEnricher(t *testing.T) {
+	calls := 0
+	wrapperrors.SetGlobalEnricher(func(e wrapperrors.ErrorWrapper) wrapperrors.ErrorWrapper {
+		calls++
+		return e.WithField("service", "cars")
+	})
+	defer wrapperrors.SetGlobalEnricher(nil)
+
+	err := wrapperrors.New("not_found", sql.ErrNoRows)
+	assert.Equal(t, "cars", wrapperrors.Fields(err)["service"])
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, "cars", wrapperrors.Fields(wrapperrors.Wrap(err, "car lookup"))["service"])
+	assert.Equal(t, 2, calls)
+
+	definition := wrapperrors.Define("enricher_not_found", http.StatusNotFound)
+	assert.Equal(t, "cars", wrapperrors.Fields(definition)["service"])
+	assert.Equal(t, 3, calls)
+	assert.Contains(t, wrapperrors.Fields(definition.FromDefinition(nil)), "service")
+	assert.Equal(t, 4, calls)
+}
+
+func TestSetGlobalEnricher_NilResult(t *testing.T) {
+	wrapperrors.SetGlobalEnricher(func(e wrapperrors.ErrorWrapper) wrapperrors.ErrorWrapper {
+		return nil
+	})
+	defer wrapperrors.SetGlobalEnricher(nil)
+	assert.Equal(t, "not_found", wrapperrors.Code(wrapperrors.New("not_found", nil)))
+}
+
+func TestSetGlobalEnricher_CloneFrozen(t *testing.T) {
+	wrapperrors.SetGlobalEnricher(func(e wrapperrors.ErrorWrapper) wrapperrors.ErrorWrapper {
+		return e.Clone().WithField("service", "cars")
+	})
+	definition := wrapperrors.Define("enricher_clone_error", http.StatusBadRequest)
+	wrapperrors.SetGlobalEnricher(nil)
+
+	before := definition.Error()
+	assert.NotSame(t, definition, definition.WithMessage("mutated"))
+	assert.Equal(t, before, definition.Error())
+}
@@ -2,15 +2,18 @@ package tests
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/felipewom/go-wrapperrors/wrapperrors"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"strings"
 	"testing"
 )
 
 func TestNewError_Empty(t *testing.T) {
-	expected1 := "code: [testing_error]"
+	expected1 := "code: [testing_error]; message: [message a, message b]; status: [0]"
 	wrappedError := wrapperrors.New("testing_error", nil).
 		WithStatus(0).
 		WithMessage("message a").
@@ -31,11 +34,442 @@ func TestNewErrorFromRaw(t *testing.T) {
 func TestNewErrorFromDefinition(t *testing.T) {
 	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
 	errMsg := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found in the database")
-	assert.EqualValues(t, "cause: [sql: no rows in result set]; code: [not_found]", errMsg.Error())
+	assert.EqualValues(t, "cause: [sql: no rows in result set]; code: [not_found]; message: [car has not been found in the database]; status: [404]", errMsg.Error())
 	assert.EqualValues(t, "{\"code\": [\"not_found\"], \"message\": [\"car has not been found in the database\"], \"status\": [{\"message\": \"Not Found\", \"code\": 404}], \"cause\": \"sql: no rows in result set\"}", errMsg.String())
 }
 
+func TestDefineInferred(t *testing.T) {
+	notFound := wrapperrors.DefineInferred("404_not_found")
+	assert.Equal(t, "not_found", wrapperrors.Code(notFound))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(notFound))
+
+	plain := wrapperrors.DefineInferred("plain")
+	assert.Equal(t, "plain", wrapperrors.Code(plain))
+	assert.Equal(t, http.StatusInternalServerError, wrapperrors.GetStatusCode(plain))
+
+	assert.Equal(t, "2fa_required", wrapperrors.Code(wrapperrors.DefineInferred("2fa_required")))
+	assert.Equal(t, "999_unknown", wrapperrors.Code(wrapperrors.DefineInferred("999_unknown")))
+}
+
+func TestCodePath(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows).WithCode("car")
+	assert.Equal(t, "not_found.car", wrapperrors.CodePath(wrappedError, "."))
+	assert.Equal(t, "not_found/car", wrapperrors.CodePath(wrappedError, "/"))
+	assert.Equal(t, "not_found; car", wrapperrors.Code(wrappedError))
+	assert.Equal(t, "", wrapperrors.CodePath(sql.ErrNoRows, "."))
+}
+
+func TestPeek(t *testing.T) {
+	code, status, ok := wrapperrors.Peek(wrapperrors.New("not_found", sql.ErrNoRows).WithCode("car").WithStatus(http.StatusNotFound))
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", code)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	code, status, ok = wrapperrors.Peek(wrapperrors.New("not_found", nil))
+	assert.True(t, ok)
+	assert.Equal(t, "not_found", code)
+	assert.Equal(t, http.StatusInternalServerError, status)
+
+	_, _, ok = wrapperrors.Peek(sql.ErrNoRows)
+	assert.False(t, ok)
+
+	err := wrapperrors.New("not_found", nil).WithStatus(http.StatusNotFound)
+	assert.Zero(t, testing.AllocsPerRun(100, func() { wrapperrors.Peek(err) }))
+}
+
+func TestNewErrorFromDefinition_Defaults(t *testing.T) {
+	definition := wrapperrors.Define("not_found", http.StatusNotFound).Clone().
+		WithCode("car").
+		WithMessage("resource has not been found").
+		WithLevel(wrapperrors.LevelWarning)
+	wrappedError := definition.FromDefinition(sql.ErrNoRows)
+	assert.Equal(t, "not_found; car", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(wrappedError))
+	assert.Equal(t, "resource has not been found", wrapperrors.Message(wrappedError))
+	assert.Equal(t, wrapperrors.LevelWarning, wrapperrors.GetLevel(wrappedError))
+	assert.True(t, errors.Is(wrappedError, definition))
+	assert.True(t, errors.Is(wrappedError, sql.ErrNoRows))
+
+	wrappedError.WithMessage("car has not been found")
+	assert.Equal(t, "resource has not been found", wrapperrors.Message(definition))
+}
+
 func TestExpectedNewError(t *testing.T) {
 	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
 	assert.Error(t, notFound)
 }
+
+func TestMapMessages(t *testing.T) {
+	original := wrapperrors.New("testing_error", nil).
+		WithMessage("message a").
+		WithMessage("message b")
+	mapped := wrapperrors.MapMessages(original, strings.ToUpper)
+	assert.Equal(t, "{\"code\": [\"testing_error\"], \"message\": [\"MESSAGE A\", \"MESSAGE B\"]}", mapped.String())
+	assert.Equal(t, "{\"code\": [\"testing_error\"], \"message\": [\"message a\", \"message b\"]}", original.String())
+}
+
+func TestCause(t *testing.T) {
+	inner := wrapperrors.New("inner_error", sql.ErrNoRows)
+	outer := wrapperrors.New("outer_error", inner)
+	assert.Equal(t, inner, wrapperrors.Cause(outer))
+	assert.Equal(t, sql.ErrNoRows, wrapperrors.Cause(inner))
+	assert.Nil(t, wrapperrors.Cause(wrapperrors.New("testing_error", nil)))
+	assert.Nil(t, wrapperrors.Cause(sql.ErrNoRows))
+}
+
+func TestAddStatusTextResolver(t *testing.T) {
+	wrapperrors.AddStatusTextResolver(func(status int) (string, bool) {
+		if status == 1001 {
+			return "Domain Failure", true
+		}
+		return "", false
+	})
+	wrapperrors.AddStatusTextResolver(func(status int) (string, bool) {
+		if status >= 1000 && status < 1100 {
+			return "Domain Error", true
+		}
+		return "", false
+	})
+	assert.Contains(t, wrapperrors.New("first", nil).WithStatus(1001).String(), "{\"message\": \"Domain Failure\", \"code\": 1001}")
+	assert.Contains(t, wrapperrors.New("second", nil).WithStatus(1002).String(), "{\"message\": \"Domain Error\", \"code\": 1002}")
+	assert.Contains(t, wrapperrors.New("third", nil).WithStatus(http.StatusNotFound).String(), "{\"message\": \"Not Found\", \"code\": 404}")
+	assert.Contains(t, wrapperrors.New("fourth", nil).WithStatus(2001).String(), "{\"message\": \"2001\", \"code\": 2001}")
+}
+
+func TestWithCause_Multiple(t *testing.T) {
+	first := errors.New("first cause")
+	second := errors.New("second cause")
+	wrappedError := wrapperrors.New("testing_error", first).WithCause(second)
+	assert.True(t, errors.Is(wrappedError, first))
+	assert.True(t, errors.Is(wrappedError, second))
+	assert.Equal(t, "cause: [first cause, second cause]; code: [testing_error]", wrappedError.Error())
+}
+
+func TestWithCauses(t *testing.T) {
+	first := errors.New("first cause")
+	second := errors.New("second cause")
+	wrappedError := wrapperrors.New("testing_error", nil).WithCauses(first, nil, second)
+	assert.True(t, errors.Is(wrappedError, first))
+	assert.True(t, errors.Is(wrappedError, second))
+	assert.Equal(t, "cause: [first cause, second cause]; code: [testing_error]", wrappedError.Error())
+}
+
+func TestIsUserFacing(t *testing.T) {
+	badRequest := wrapperrors.Define("invalid_payload", http.StatusBadRequest)
+	internal := wrapperrors.Define("internal", http.StatusInternalServerError)
+	assert.True(t, wrapperrors.IsUserFacing(badRequest.FromDefinition(nil)))
+	assert.False(t, wrapperrors.IsUserFacing(internal.FromDefinition(nil)))
+	assert.True(t, wrapperrors.IsUserFacing(internal.FromDefinition(nil).WithUserFacing(true)))
+	assert.False(t, wrapperrors.IsUserFacing(badRequest.FromDefinition(nil).WithUserFacing(false)))
+	assert.False(t, wrapperrors.IsUserFacing(errors.New("plain")))
+}
+
+func TestError_SpecialCharacters(t *testing.T) {
+	wrappedError := wrapperrors.New("quoted \"code\", with comma", errors.New("cause; with [brackets]")).
+		WithMessage("plain message").
+		WithStatus(http.StatusBadRequest)
+	expected := `cause: ["cause; with [brackets]"]; code: ["quoted \"code\", with comma"]; message: [plain message]; status: [400]`
+	assert.Equal(t, expected, wrappedError.Error())
+}
+
+func TestError_NestedWrappers(t *testing.T) {
+	wrappedError := wrapperrors.Wrapf(wrapperrors.Wrapf(wrapperrors.New("nf", errors.New(`db "x"`)).WithStatus(http.StatusNotFound), "a"), "b")
+	expected := `cause: [cause: [cause: ["db \"x\""]; code: [nf]; status: [404]]; code: [nf]; message: [a]; status: [404]]; ` +
+		`code: [nf]; message: [b]; status: [404]`
+	assert.Equal(t, expected, wrappedError.Error())
+}
+
+func TestJson(t *testing.T) {
+	wrappedError := wrapperrors.New("quoted \"code\", with comma", errors.New("code: [fake]")).
+		WithMessage("message a").
+		WithStatus(http.StatusBadRequest)
+	jsonMap := wrappedError.Json()
+	assert.Equal(t, []string{"quoted \"code\", with comma"}, jsonMap["code"])
+	assert.Equal(t, []string{"message a"}, jsonMap["message"])
+	assert.Equal(t, "code: [fake]", jsonMap["cause"])
+	_, err := json.Marshal(jsonMap)
+	assert.NoError(t, err)
+}
+
+func TestWithMessagef(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", nil).WithMessagef("car %s has not been found after %d attempts", "abc", 3)
+	assert.Equal(t, "code: [not_found]; message: [car abc has not been found after 3 attempts]", wrappedError.Error())
+	assert.Equal(t, "{\"code\": [\"not_found\"], \"message\": [\"car abc has not been found after 3 attempts\"]}", wrappedError.String())
+}
+
+func TestNewf(t *testing.T) {
+	wrappedError := wrapperrors.Newf("not_found", "car %s has not been found", "abc")
+	assert.Equal(t, "code: [not_found]; message: [car abc has not been found]", wrappedError.Error())
+	assert.Equal(t, "{\"code\": [\"not_found\"], \"message\": [\"car abc has not been found\"]}", wrappedError.String())
+}
+
+func TestErrorf(t *testing.T) {
+	timeout := errors.New("timeout")
+	wrappedError := wrapperrors.Errorf("not_found", "car %s has not been found: %w and %w", "abc", sql.ErrNoRows, timeout)
+	assert.True(t, errors.Is(wrappedError, sql.ErrNoRows))
+	assert.True(t, errors.Is(wrappedError, timeout))
+	assert.Equal(t, "cause: [sql: no rows in result set, timeout]; code: [not_found]; message: [car abc has not been found: sql: no rows in result set and timeout]", wrappedError.Error())
+	assert.Equal(t, 2, wrapperrors.Count(wrappedError))
+}
+
+func TestIs(t *testing.T) {
+	notFound := wrapperrors.Define("is_not_found", http.StatusNotFound)
+	err := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	assert.True(t, wrapperrors.Is(err, notFound))
+	assert.True(t, wrapperrors.Is(err.WithCode("car"), notFound))
+	assert.True(t, wrapperrors.Is(wrapperrors.Wrap(err, "car lookup"), notFound))
+	assert.False(t, wrapperrors.Is(err, wrapperrors.Define("is_conflict", http.StatusConflict)))
+	assert.False(t, wrapperrors.Is(sql.ErrNoRows, notFound))
+	assert.True(t, wrapperrors.Is(sql.ErrNoRows, sql.ErrNoRows))
+}
+
+func TestSameKind(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	first := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	second := notFound.FromDefinition(errors.New("missing")).WithMessage("person has not been found")
+	assert.True(t, wrapperrors.SameKind(first, second))
+	assert.True(t, wrapperrors.SameKind(second, first))
+}
+
+func TestSameKind_Different(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound).FromDefinition(sql.ErrNoRows)
+	invalid := wrapperrors.Define("invalid_payload", http.StatusNotFound).FromDefinition(sql.ErrNoRows)
+	gone := wrapperrors.Define("not_found", http.StatusGone).FromDefinition(sql.ErrNoRows)
+	assert.False(t, wrapperrors.SameKind(notFound, invalid))
+	assert.False(t, wrapperrors.SameKind(notFound, gone))
+	assert.False(t, wrapperrors.SameKind(notFound, sql.ErrNoRows))
+}
+
+func TestClone(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	original := notFound.FromDefinition(sql.ErrNoRows).WithMessage("message a").WithField("id", "abc")
+	expected := original.String()
+	clone := original.Clone().
+		WithMessage("message b").
+		WithStatus(http.StatusGone).
+		WithCause(errors.New("another cause")).
+		WithField("id", "def")
+	assert.Equal(t, expected, original.String())
+	assert.NotEqual(t, expected, clone.String())
+	assert.Equal(t, "abc", wrapperrors.Fields(original)["id"])
+	assert.Equal(t, "def", wrapperrors.Fields(clone)["id"])
+}
+
+func TestClone_Definition(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	expected := notFound.String()
+	clone := notFound.Clone().WithMessage("car has not been found")
+	assert.Equal(t, expected, notFound.String())
+	assert.Contains(t, clone.String(), "car has not been found")
+}
+
+func TestSequence(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", nil)
+	assert.Greater(t, wrapperrors.Sequence(first), uint64(0))
+	assert.Greater(t, wrapperrors.Sequence(second), wrapperrors.Sequence(first))
+	assert.Equal(t, wrapperrors.Sequence(first), wrapperrors.Sequence(first.Clone()))
+	assert.Equal(t, uint64(0), wrapperrors.Sequence(errors.New("plain")))
+}
+
+func TestTag(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	assert.Equal(t, "not_found:404", wrapperrors.Tag(notFound.FromDefinition(sql.ErrNoRows)))
+	wrapped := wrapperrors.Wrap(notFound.FromDefinition(sql.ErrNoRows), "lookup")
+	assert.Equal(t, "not_found:404", wrapperrors.Tag(wrapped))
+	assert.Equal(t, "unknown:500", wrapperrors.Tag(errors.New("plain")))
+}
+
+func TestWithDefinitionStatus(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	gone := notFound.WithDefinitionStatus(http.StatusGone)
+	assert.Equal(t, http.StatusGone, wrapperrors.GetStatusCode(gone))
+	assert.Equal(t, "[{\"message\": \"Gone\", \"code\": 410}]", wrapperrors.Status(gone))
+	assert.Equal(t, "not_found", wrapperrors.Code(gone))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(notFound))
+	assert.Equal(t, http.StatusGone, wrapperrors.GetStatusCode(gone.FromDefinition(sql.ErrNoRows)))
+}
+
+func TestWithStatusText(t *testing.T) {
+	wrappedError := wrapperrors.New("client_closed", nil).WithStatusText(499, "Client Closed Request")
+	assert.Equal(t, "[{\"message\": \"Client Closed Request\", \"code\": 499}]", wrapperrors.Status(wrappedError))
+	assert.Equal(t, 499, wrapperrors.GetStatusCode(wrappedError))
+}
+
+func TestClone_NoSliceAliasing(t *testing.T) {
+	original := wrapperrors.New("testing_error", errors.New("cause")).
+		WithMessage("message a").
+		WithMessage("message b").
+		WithMessage("message c")
+	expected := original.String()
+	clone := original.Clone()
+	mapped := wrapperrors.MapMessages(original, strings.ToUpper)
+	for i := 0; i < 100; i++ {
+		clone.WithMessagef("clone message %d", i).WithCause(errors.New("clone cause")).WithStatus(http.StatusGone)
+		mapped.WithMessagef("mapped message %d", i)
+	}
+	assert.Equal(t, expected, original.String())
+	assert.Equal(t, "message a; message b; message c", wrapperrors.Message(original))
+
+	original.WithMessage("message d")
+	assert.Equal(t, "message a; message b; message c; clone message 0", strings.Join(strings.Split(wrapperrors.Message(clone), "; ")[:4], "; "))
+	assert.Equal(t, "MESSAGE A; MESSAGE B; MESSAGE C; mapped message 0", strings.Join(strings.Split(wrapperrors.Message(mapped), "; ")[:4], "; "))
+}
+
+func TestWithDefinitionStatus_NoSliceAliasing(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	gone := notFound.WithDefinitionStatus(http.StatusGone)
+	for i := 0; i < 100; i++ {
+		gone.WithStatus(http.StatusGone)
+	}
+	assert.Equal(t, "[{\"message\": \"Not Found\", \"code\": 404}]", wrapperrors.Status(notFound))
+}
+
+func TestWithDefinitionStatus_Frozen(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	gone := notFound.WithDefinitionStatus(http.StatusGone)
+	before := gone.Error()
+	request := gone.WithCause(errors.New("boom")).WithMessage("req 1")
+	assert.Equal(t, before, gone.Error())
+	assert.Equal(t, "cause: [boom]; code: [not_found]; message: [req 1]; status: [410]", request.Error())
+	assert.NotEqual(t, wrapperrors.Sequence(notFound), wrapperrors.Sequence(gone))
+}
+
+func TestMustDefine(t *testing.T) {
+	assert.Panics(t, func() { wrapperrors.MustDefine("", http.StatusInternalServerError) })
+	assert.Panics(t, func() { wrapperrors.Must(nil) })
+	assert.NotPanics(t, func() {
+		definition := wrapperrors.MustDefine("ok", http.StatusInternalServerError)
+		assert.Equal(t, "ok", wrapperrors.Code(definition))
+	})
+}
+
+func TestWrapf(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	inner := notFound.FromDefinition(sql.ErrNoRows)
+	wrappedError := wrapperrors.Wrapf(inner, "car %s has not been found", "abc")
+	assert.Equal(t, "car abc has not been found", wrapperrors.Message(wrappedError))
+	assert.Equal(t, "not_found", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(wrappedError))
+	assert.Equal(t, inner, wrapperrors.Cause(wrappedError))
+	assert.True(t, errors.Is(wrappedError, sql.ErrNoRows))
+
+	plain := wrapperrors.Wrapf(sql.ErrConnDone, "attempt %d", 2)
+	assert.Equal(t, "attempt 2", wrapperrors.Message(plain))
+	assert.Equal(t, sql.ErrConnDone, wrapperrors.Cause(plain))
+}
+
+func TestWrap_InheritsFields(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows).WithField("id", "abc").WithField("table", "car")
+	middle := wrapperrors.Wrapf(fmt.Errorf("lookup: %w", inner), "lookup failed").WithField("table", "person")
+	outer := wrapperrors.Wrap(middle, "request failed").WithField("request_id", "req-1")
+	assert.Equal(t, map[string]interface{}{"id": "abc", "table": "person", "request_id": "req-1"}, wrapperrors.Fields(outer))
+	assert.Equal(t, map[string]interface{}{"id": "abc", "table": "car"}, wrapperrors.Fields(inner))
+
+	withCause := wrapperrors.New("internal", nil).WithField("id", "xyz").WithCause(inner)
+	assert.Equal(t, map[string]interface{}{"id": "xyz", "table": "car"}, wrapperrors.Fields(withCause))
+}
+
+func TestIs_SingleCode(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	internal := wrapperrors.Define("internal", http.StatusInternalServerError)
+	err := notFound.FromDefinition(sql.ErrNoRows)
+	assert.True(t, err.Is(notFound))
+	assert.False(t, err.Is(internal))
+	assert.False(t, err.Is(sql.ErrNoRows))
+	assert.True(t, errors.Is(err, notFound))
+	assert.True(t, errors.Is(wrapperrors.Wrap(err, "lookup"), notFound))
+	assert.False(t, errors.Is(err, internal))
+}
+
+func TestIs_MultipleCodes(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	flat := wrapperrors.Flatten(wrapperrors.New("not_found", wrapperrors.New("car_not_found", nil)))
+	sameCodes := wrapperrors.Flatten(wrapperrors.New("not_found", wrapperrors.New("car_not_found", nil)))
+	otherOrder := wrapperrors.Flatten(wrapperrors.New("car_not_found", wrapperrors.New("not_found", nil)))
+	assert.True(t, flat.Is(sameCodes))
+	assert.False(t, flat.Is(otherOrder))
+	assert.True(t, flat.Is(notFound))
+	assert.False(t, notFound.Is(flat))
+}
+
+func TestIs_NilCodes(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	noCode := wrapperrors.Flatten(errors.New("plain"))
+	assert.NotPanics(t, func() {
+		assert.False(t, noCode.Is(notFound))
+		assert.False(t, notFound.Is(noCode))
+		assert.False(t, noCode.Is(wrapperrors.Flatten(errors.New("other"))))
+		assert.False(t, noCode.Is(nil))
+	})
+}
+
+func TestCategory(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound).FromDefinition(nil)
+	internal := wrapperrors.Define("internal", http.StatusInternalServerError).FromDefinition(nil)
+	plain := errors.New("plain")
+
+	assert.Equal(t, "client", wrapperrors.Category(notFound))
+	assert.True(t, wrapperrors.IsClientError(notFound))
+	assert.False(t, wrapperrors.IsServerError(notFound))
+
+	assert.Equal(t, "server", wrapperrors.Category(internal))
+	assert.False(t, wrapperrors.IsClientError(internal))
+	assert.True(t, wrapperrors.IsServerError(internal))
+
+	assert.Equal(t, "unknown", wrapperrors.Category(plain))
+	assert.False(t, wrapperrors.IsClientError(plain))
+	assert.False(t, wrapperrors.IsServerError(plain))
+}
+
+func TestWithCode(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	err := notFound.FromDefinition(sql.ErrNoRows).WithCode("car_not_found").WithCode("car_archived")
+	assert.Equal(t, "not_found; car_not_found; car_archived", wrapperrors.Code(err))
+	assert.Equal(t, "not_found.car_not_found.car_archived:404", wrapperrors.Tag(err))
+	assert.True(t, err.Is(notFound))
+	assert.True(t, errors.Is(err, notFound))
+	assert.False(t, errors.Is(err, wrapperrors.Define("car_not_found", http.StatusNotFound)))
+}
+
+func TestError_SingleCode(t *testing.T) {
+	assert.Equal(t, "code: [not_found]", wrapperrors.New("not_found", nil).Error())
+	assert.Equal(t, "code: [\"not_found, gone\"]", wrapperrors.New("not_found, gone", nil).Error())
+	assert.Equal(t, "code: [\"\"]", wrapperrors.New("", nil).Error())
+}
+
+func TestDetach(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	original := notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithQuery("SELECT * FROM car WHERE id = ?", "abc")
+	expected := original.String()
+	detached := original.Detach()
+	assert.Nil(t, wrapperrors.Cause(detached))
+	assert.Equal(t, "code: [not_found]; message: [car has not been found]; status: [404]", detached.Error())
+	assert.NotContains(t, detached.Debug(), "SELECT")
+	assert.Equal(t, expected, original.String())
+	assert.Equal(t, sql.ErrNoRows, wrapperrors.Cause(original))
+}
+
+func TestSetDefaultStatus(t *testing.T) {
+	wrapperrors.SetDefaultStatus(http.StatusBadRequest)
+	defer wrapperrors.SetDefaultStatus(http.StatusInternalServerError)
+
+	def := wrapperrors.Define("default_status_error", 0)
+	assert.Equal(t, http.StatusBadRequest, wrapperrors.GetStatusCode(def))
+	assert.Equal(t, http.StatusBadRequest, wrapperrors.GetStatusCode(errors.New("plain")))
+	assert.Equal(t, http.StatusBadRequest, wrapperrors.ToProblem9457(def.FromDefinition(nil))["status"])
+}
+
+func TestDefinition_BuildersReturnClone(t *testing.T) {
+	before := wrapperrors.UnknownError.Error()
+	wrappedError := wrapperrors.UnknownError.WithCause(errors.New("boom")).WithMessage("something failed")
+	assert.Equal(t, before, wrapperrors.UnknownError.Error())
+	assert.Equal(t, "cause: [boom]; code: [unknown_error]; message: [something failed]; status: [500]", wrappedError.Error())
+	assert.True(t, errors.Is(wrappedError, wrapperrors.UnknownError))
+
+	wrapperrors.InternalError.WithField("id", "abc").WithStatus(http.StatusBadGateway)
+	assert.Empty(t, wrapperrors.Fields(wrapperrors.InternalError))
+	assert.Equal(t, http.StatusInternalServerError, wrapperrors.GetStatusCode(wrapperrors.InternalError))
+}
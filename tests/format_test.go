@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestSetFormatTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("error").Parse(
+		`{{range .Code}}[{{.}}]{{end}} {{range .Status}}{{.}}{{end}}: {{range .Message}}{{.}}{{end}} ({{.Cause}}) id={{.Fields.id}}`))
+	wrapperrors.SetFormatTemplate(tmpl)
+	defer wrapperrors.SetFormatTemplate(nil)
+
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	wrappedError := notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithField("id", "abc")
+	assert.Equal(t, "[not_found] 404: car has not been found (sql: no rows in result set) id=abc", wrappedError.Error())
+}
+
+func TestSetFormatTemplate_Reset(t *testing.T) {
+	wrapperrors.SetFormatTemplate(template.Must(template.New("error").Parse(`custom`)))
+	wrapperrors.SetFormatTemplate(nil)
+
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows)
+	assert.Equal(t, "cause: [sql: no rows in result set]; code: [not_found]", wrappedError.Error())
+}
+
+func TestSetSeparator(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", errors.New("first")).
+		WithCause(errors.New("second")).
+		WithStatus(http.StatusNotFound)
+	assert.Equal(t, "cause: [first, second]; code: [not_found]; status: [404]", wrappedError.Error())
+	assert.Equal(t, "first; second", wrappedError.Json()["cause"])
+
+	wrapperrors.SetSeparator(" | ")
+	defer wrapperrors.SetSeparator("")
+	assert.Equal(t, "cause: [first, second] | code: [not_found] | status: [404]", wrappedError.Error())
+	assert.Equal(t, "first | second", wrappedError.Json()["cause"])
+	assert.Contains(t, wrappedError.String(), `"cause": "first | second"`)
+	assert.False(t, strings.HasSuffix(wrappedError.Error(), " | "))
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestStableID_Deterministic(t *testing.T) {
+	first := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	second := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	assert.Equal(t, wrapperrors.StableID(first, "salt"), wrapperrors.StableID(second, "salt"))
+	assert.Len(t, wrapperrors.StableID(first, "salt"), 64)
+}
+
+func TestStableID_SaltSensitive(t *testing.T) {
+	err := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	assert.NotEqual(t, wrapperrors.StableID(err, "salt a"), wrapperrors.StableID(err, "salt b"))
+}
+
+func TestStableID_ContentSensitive(t *testing.T) {
+	notFound := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	gone := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusGone)
+	assert.NotEqual(t, wrapperrors.StableID(notFound, "salt"), wrapperrors.StableID(gone, "salt"))
+}
+
+func TestSlug(t *testing.T) {
+	err := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	slug := wrapperrors.Slug(err)
+	assert.Regexp(t, "^not_found-[0-9a-f]{6}$", slug)
+	assert.Equal(t, slug, wrapperrors.Slug(wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)))
+}
+
+func TestSlug_FilesystemSafe(t *testing.T) {
+	slug := wrapperrors.Slug(wrapperrors.New("../etc/passwd: \"bad\"", nil))
+	assert.Regexp(t, "^[A-Za-z0-9_-]+$", slug)
+	assert.Regexp(t, "^error-[0-9a-f]{6}$", wrapperrors.Slug(sql.ErrNoRows))
+}
+
+func TestFingerprint(t *testing.T) {
+	first := wrapperrors.Wrap(wrapperrors.New("not_found", sql.ErrNoRows).WithMessage("car abc has not been found"), "repository")
+	second := wrapperrors.Wrap(wrapperrors.New("not_found", sql.ErrNoRows).WithMessage("car xyz has not been found"), "repository").
+		WithField("id", "xyz")
+	assert.Equal(t, wrapperrors.Fingerprint(first), wrapperrors.Fingerprint(second))
+	assert.Len(t, wrapperrors.Fingerprint(first), 64)
+
+	other := wrapperrors.New("conflict", sql.ErrNoRows)
+	assert.NotEqual(t, wrapperrors.Fingerprint(first), wrapperrors.Fingerprint(other))
+}
+
+func TestWithFingerprint(t *testing.T) {
+	err := wrapperrors.New("not_found", sql.ErrNoRows).WithFingerprint("car-lookup")
+	assert.Equal(t, "car-lookup", wrapperrors.Fingerprint(err))
+	assert.Equal(t, "car-lookup", wrapperrors.Fingerprint(wrapperrors.Wrap(err, "repository")))
+}
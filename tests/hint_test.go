@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithHint(t *testing.T) {
+	unauthorized := wrapperrors.Define("hint_unauthorized", http.StatusUnauthorized)
+	err := unauthorized.FromDefinition(errors.New("invalid token")).
+		WithMessage("request has not been authenticated").
+		WithHint("check the API key")
+	assert.Equal(t, "check the API key", wrapperrors.Hint(err))
+	assert.Equal(t, "check the API key", err.Json()["hint"])
+	assert.Equal(t, "check the API key", wrapperrors.ToProblem9457(err)["hint"])
+
+	body, marshalErr := json.Marshal(err.Json())
+	assert.NoError(t, marshalErr)
+	assert.Contains(t, string(body), `"hint":"check the API key"`)
+
+	assert.Equal(t, "check the API key", wrapperrors.Hint(wrapperrors.Wrap(err, "calling cars API")))
+	assert.NotContains(t, unauthorized.FromDefinition(nil).Json(), "hint")
+	assert.Empty(t, wrapperrors.Hint(errors.New("plain")))
+}
+
+func TestHint_Serializers(t *testing.T) {
+	err := wrapperrors.New("hint_unauthorized", nil).WithStatus(http.StatusUnauthorized).WithHint("check the API key")
+	buff := bytes.Buffer{}
+	assert.NoError(t, err.EncodeJSON(&buff))
+	assert.Contains(t, buff.String(), `"hint":"check the API key"`)
+	assert.Equal(t, "check the API key", err.Response().Hint)
+
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, err)
+	decoded, decodeErr := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, "check the API key", wrapperrors.Hint(decoded))
+}
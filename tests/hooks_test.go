@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestSetOnCreate(t *testing.T) {
+	counts := make(map[string]int)
+	wrapperrors.SetOnCreate(func(e wrapperrors.ErrorWrapper) {
+		counts[wrapperrors.Tag(e)]++
+	})
+	defer wrapperrors.SetOnCreate(nil)
+
+	notFound := wrapperrors.Define("hook_not_found", http.StatusNotFound)
+	assert.Empty(t, counts)
+	wrapperrors.New("hook_error", nil)
+	notFound.FromDefinition(nil)
+	wrapperrors.Wrap(errors.New("plain"), "wrapped")
+	wrapperrors.Wrap(notFound.FromDefinition(nil), "wrapped")
+	assert.Equal(t, map[string]int{
+		"hook_error:500":     1,
+		"hook_not_found:404": 3,
+		"unknown_error:500":  1,
+	}, counts)
+}
+
+func TestSetOnCreate_Nil(t *testing.T) {
+	wrapperrors.SetOnCreate(nil)
+	assert.NotPanics(t, func() { wrapperrors.New("hook_error", nil) })
+}
+
+func TestSetOnCreate_DefinitionBuilders(t *testing.T) {
+	var created []wrapperrors.ErrorWrapper
+	wrapperrors.SetOnCreate(func(e wrapperrors.ErrorWrapper) {
+		created = append(created, e)
+	})
+	defer wrapperrors.SetOnCreate(nil)
+
+	notFound := wrapperrors.Define("hook_builder_not_found", http.StatusNotFound)
+	err := notFound.WithStatus(http.StatusGone).WithMessage("car not found")
+	assert.Len(t, created, 1)
+	assert.Equal(t, "hook_builder_not_found:410", wrapperrors.Tag(created[0]))
+	assert.Greater(t, wrapperrors.Sequence(err), wrapperrors.Sequence(notFound))
+}
@@ -0,0 +1,257 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func serveRecovered(handler http.HandlerFunc) (*httptest.ResponseRecorder, map[string]interface{}) {
+	recorder := httptest.NewRecorder()
+	wrapperrors.Recoverer(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	body := make(map[string]interface{})
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder, body
+}
+
+func TestRecoverer_Error(t *testing.T) {
+	recorder, body := serveRecovered(func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.New("boom"))
+	})
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, []interface{}{"internal_error"}, body["code"])
+	assert.NotContains(t, body, "cause")
+	assert.NotContains(t, recorder.Body.String(), "boom")
+}
+
+func TestRecoverer_String(t *testing.T) {
+	recorder, body := serveRecovered(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.NotContains(t, body, "cause")
+	assert.NotContains(t, recorder.Body.String(), "boom")
+}
+
+func TestRecoverer_Arbitrary(t *testing.T) {
+	recorder, body := serveRecovered(func(w http.ResponseWriter, r *http.Request) {
+		panic(42)
+	})
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.NotContains(t, body, "cause")
+}
+
+func TestRecoverer_Logged(t *testing.T) {
+	logged := bytes.Buffer{}
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+	serveRecovered(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	assert.Contains(t, logged.String(), "recovered from panic")
+	assert.Contains(t, logged.String(), "boom")
+}
+
+func TestRecoverer_NoPanic(t *testing.T) {
+	recorder, _ := serveRecovered(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestWriteResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	wrapperrors.WriteResponse(recorder, notFound.FromDefinition(errors.New("missing")).
+		WithMessage("car abc missing from table car").
+		WithPublicMessage("car not found"))
+	body := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, []interface{}{"car not found"}, body["message"])
+	assert.NotContains(t, recorder.Body.String(), "table car")
+	assert.NotContains(t, body, "cause")
+}
+
+func TestResponseBody_Internals(t *testing.T) {
+	err := wrapperrors.New("not_found", errors.New("select * from car")).WithField("table", "car")
+	body := wrapperrors.ResponseBody(err)
+	assert.NotContains(t, body, "cause")
+	assert.NotContains(t, body, "fields")
+
+	validationError := wrapperrors.NewValidationError().AddFieldError("name", "is required")
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, wrapperrors.ResponseBody(validationError)["fields"])
+
+	wrapperrors.SetExposeInternals(true)
+	defer wrapperrors.SetExposeInternals(false)
+	body = wrapperrors.ResponseBody(err)
+	assert.Equal(t, "select * from car", body["cause"])
+	assert.Equal(t, map[string]interface{}{"table": "car"}, body["fields"])
+}
+
+func TestWriteResponse_DefaultPublicMessage(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, wrapperrors.New("not_found", nil).WithMessage("car abc missing from table car"))
+	body := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, []interface{}{"An error occurred."}, body["message"])
+	assert.NotContains(t, recorder.Body.String(), "table car")
+}
+
+func TestWithHeader(t *testing.T) {
+	unauthorized := wrapperrors.Define("unauthorized", http.StatusUnauthorized)
+	err := unauthorized.FromDefinition(nil).
+		WithHeader("WWW-Authenticate", `Bearer realm="api"`).
+		WithHeader("WWW-Authenticate", `Basic realm="api"`)
+	assert.Equal(t, []string{`Bearer realm="api"`, `Basic realm="api"`}, wrapperrors.Headers(err).Values("WWW-Authenticate"))
+
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, err)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, []string{`Bearer realm="api"`, `Basic realm="api"`}, recorder.Header().Values("WWW-Authenticate"))
+	assert.Empty(t, wrapperrors.Headers(errors.New("plain")))
+}
+
+func TestFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/cars/42?force=true", nil)
+	r.Header.Set("X-Request-ID", "req-1")
+	r.Header.Set("X-Tenant", "acme")
+	r.Header.Set("Authorization", "Bearer secret")
+
+	err := wrapperrors.FromRequest(r, "invalid_payload")
+	assert.Equal(t, "invalid_payload", wrapperrors.Code(err))
+	assert.Equal(t, map[string]interface{}{"method": "POST", "path": "/cars/42", "X-Request-ID": "req-1"}, wrapperrors.Fields(err))
+
+	wrapperrors.SetCaptureHeaders([]string{"X-Tenant", "Authorization"})
+	defer wrapperrors.SetCaptureHeaders([]string{"X-Request-ID"})
+	err = wrapperrors.FromRequest(r, "invalid_payload")
+	assert.Equal(t, map[string]interface{}{"method": "POST", "path": "/cars/42", "X-Tenant": "acme"}, wrapperrors.Fields(err))
+}
+
+func recoverPanic(value interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = wrapperrors.Recover(r)
+		}
+	}()
+	panic(value)
+}
+
+func TestRecover(t *testing.T) {
+	cause := errors.New("boom")
+	err := recoverPanic(cause)
+	assert.Equal(t, "internal_error", wrapperrors.Code(err))
+	assert.True(t, errors.Is(err, cause))
+	assert.Contains(t, wrapperrors.StackTrace(err), "tests.recoverPanic")
+
+	wrapperrors.SetStackCaptureForStatusClass("4xx")
+	defer wrapperrors.SetStackCaptureForStatusClass("")
+	err = recoverPanic("boom")
+	assert.Equal(t, "cause: [boom]; code: [internal_error]; status: [500]", err.Error())
+	assert.Contains(t, wrapperrors.StackTrace(err), "tests.recoverPanic")
+
+	err = recoverPanic(42)
+	assert.Equal(t, "cause: [42]; code: [internal_error]; status: [500]", err.Error())
+	assert.Nil(t, wrapperrors.Recover(nil))
+}
+
+func TestFromHTTPResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	original := notFound.FromDefinition(errors.New("no rows")).
+		WithPublicMessage("car has not been found").
+		WithField("id", "abc")
+	wrapperrors.WriteResponse(recorder, original)
+
+	decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Equal(t, "not_found", wrapperrors.Code(decoded))
+	assert.Equal(t, "car has not been found", wrapperrors.Message(decoded))
+	assert.Nil(t, wrapperrors.Cause(decoded))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(decoded))
+	assert.NotContains(t, wrapperrors.Fields(decoded), "id")
+}
+
+func TestFromHTTPResponse_Forwarded(t *testing.T) {
+	upstream := httptest.NewRecorder()
+	wrapperrors.WriteResponse(upstream, wrapperrors.Define("not_found", http.StatusNotFound).FromDefinition(nil).
+		WithPublicMessage("car has not been found"))
+	decoded, err := wrapperrors.FromHTTPResponse(upstream.Result())
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, decoded)
+	body := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, []interface{}{"car has not been found"}, body["message"])
+}
+
+func TestFromHTTPResponse_LargeBody(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusBadGateway)
+	recorder.WriteString(strings.Repeat("x", 2<<20))
+
+	decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Len(t, wrapperrors.Message(decoded), 1<<20)
+}
+
+func TestFromHTTPResponse_ExposeInternals(t *testing.T) {
+	wrapperrors.SetExposeInternals(true)
+	defer wrapperrors.SetExposeInternals(false)
+	recorder := httptest.NewRecorder()
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	wrapperrors.WriteResponse(recorder, notFound.FromDefinition(errors.New("no rows")).WithField("id", "abc"))
+
+	decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Equal(t, "no rows", wrapperrors.Cause(decoded).Error())
+	assert.Equal(t, "abc", wrapperrors.Fields(decoded)["id"])
+}
+
+func TestFromHTTPResponse_MissingStatus(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusConflict)
+	recorder.WriteString(`{"code": ["conflict"], "message": ["car already exists"]}`)
+
+	decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Equal(t, "code: [conflict]; message: [car already exists]; status: [409]", decoded.Error())
+}
+
+func TestFromHTTPResponse_NotJSON(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusBadGateway)
+	recorder.WriteString("upstream unavailable")
+
+	decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown_error", wrapperrors.Code(decoded))
+	assert.Equal(t, "upstream unavailable", wrapperrors.Message(decoded))
+	assert.Equal(t, http.StatusBadGateway, wrapperrors.GetStatusCode(decoded))
+}
+
+func TestFromHTTPResponse_NotErrorObject(t *testing.T) {
+	for _, body := range []string{`[]`, `null`, `{}`} {
+		recorder := httptest.NewRecorder()
+		recorder.WriteHeader(http.StatusBadGateway)
+		recorder.WriteString(body)
+
+		decoded, err := wrapperrors.FromHTTPResponse(recorder.Result())
+		assert.NoError(t, err)
+		assert.Equal(t, "unknown_error", wrapperrors.Code(decoded), body)
+		assert.Equal(t, body, wrapperrors.Message(decoded), body)
+		assert.Equal(t, http.StatusBadGateway, wrapperrors.GetStatusCode(decoded), body)
+		assert.Len(t, decoded.Json()["status"], 1, body)
+	}
+}
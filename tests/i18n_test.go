@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func init() {
+	wrapperrors.RegisterMessages("en", map[string]string{
+		"i18n_not_found": "The resource has not been found",
+		"i18n_conflict":  "The resource already exists",
+	})
+	wrapperrors.RegisterMessages("pt", map[string]string{
+		"i18n_not_found": "O recurso não foi encontrado",
+	})
+}
+
+func TestLocalizedMessage(t *testing.T) {
+	notFound := wrapperrors.New("i18n_not_found", nil)
+	assert.Equal(t, "The resource has not been found", wrapperrors.LocalizedMessage(notFound, "en"))
+	assert.Equal(t, "O recurso não foi encontrado", wrapperrors.LocalizedMessage(notFound, "pt"))
+}
+
+func TestLocalizedMessage_Fallback(t *testing.T) {
+	conflict := wrapperrors.New("i18n_conflict", nil)
+	assert.Equal(t, "The resource already exists", wrapperrors.LocalizedMessage(conflict, "pt"))
+	conflict.WithMessage("car already exists")
+	assert.Equal(t, "car already exists", wrapperrors.LocalizedMessage(conflict, "pt"))
+	assert.Equal(t, "The resource already exists", wrapperrors.LocalizedMessage(conflict, "en"))
+	assert.Equal(t, "", wrapperrors.LocalizedMessage(errors.New("plain"), "pt"))
+}
+
+func TestWithLocale_Json(t *testing.T) {
+	notFound := wrapperrors.New("i18n_not_found", nil).WithMessage("car abc missing").WithLocale("pt")
+	assert.Equal(t, []string{"O recurso não foi encontrado"}, notFound.Json()["message"])
+}
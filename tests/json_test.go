@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	original := wrapperrors.New("not_found", sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithStatus(http.StatusNotFound).
+		WithField("id", "abc")
+	data, err := json.Marshal(original)
+	assert.NoError(t, err)
+
+	decoded := wrapperrors.New("", nil)
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.True(t, original.Equal(decoded))
+	assert.Equal(t, "abc", wrapperrors.Fields(decoded)["id"])
+	assert.Equal(t, original.Error(), decoded.Error())
+}
+
+func FuzzJSONRoundTrip(f *testing.F) {
+	f.Add("not_found", "car has not been found", http.StatusNotFound, "sql: no rows in result set", true)
+	f.Add("", "", 0, "", false)
+	f.Add("código", "não encontrado 🚗", http.StatusTeapot, "", true)
+	f.Add("a, b", "\"quoted\"; [bracketed]\n", -1, "cause\twith\ttabs", true)
+	f.Fuzz(func(t *testing.T, code, message string, status int, cause string, hasCause bool) {
+		if !utf8.ValidString(code) || !utf8.ValidString(message) || !utf8.ValidString(cause) {
+			t.Skip("JSON strings must be valid UTF-8")
+		}
+		original := wrapperrors.New(code, nil).WithMessage(message).WithStatus(status)
+		if hasCause {
+			original.WithCause(errors.New(cause))
+		}
+		data, err := json.Marshal(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := wrapperrors.New("", nil)
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !original.Equal(decoded) {
+			t.Fatalf("round trip mismatch: %s != %s", original.String(), decoded.String())
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestLevel_Ordering(t *testing.T) {
+	assert.True(t, wrapperrors.LevelError.AtLeast(wrapperrors.LevelWarning))
+	assert.True(t, wrapperrors.LevelError.AtLeast(wrapperrors.LevelError))
+	assert.False(t, wrapperrors.LevelInfo.AtLeast(wrapperrors.LevelWarning))
+	assert.True(t, wrapperrors.LevelCritical.AtLeast(wrapperrors.LevelDebug))
+}
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "debug", wrapperrors.LevelDebug.String())
+	assert.Equal(t, "warning", wrapperrors.LevelWarning.String())
+	assert.Equal(t, "critical", wrapperrors.LevelCritical.String())
+	assert.Equal(t, "level(42)", wrapperrors.Level(42).String())
+}
+
+func TestMaxLevel(t *testing.T) {
+	info := wrapperrors.New("info_error", nil).WithLevel(wrapperrors.LevelInfo)
+	warning := wrapperrors.New("warning_error", nil).WithLevel(wrapperrors.LevelWarning)
+	critical := wrapperrors.New("critical_error", nil).WithLevel(wrapperrors.LevelCritical)
+	assert.Equal(t, wrapperrors.LevelWarning, wrapperrors.MaxLevel(info, nil, warning))
+	assert.Equal(t, wrapperrors.LevelCritical, wrapperrors.MaxLevel(info, critical, errors.New("plain")))
+	assert.Equal(t, wrapperrors.LevelError, wrapperrors.MaxLevel(info, errors.New("plain")))
+	assert.Equal(t, wrapperrors.LevelDebug, wrapperrors.MaxLevel())
+}
+
+func TestSetDefaultLevel(t *testing.T) {
+	wrapperrors.SetDefaultLevel("level_test_not_found", wrapperrors.LevelInfo)
+	wrapperrors.SetDefaultLevel("level_test_internal", wrapperrors.LevelCritical)
+
+	assert.Equal(t, wrapperrors.LevelInfo, wrapperrors.GetLevel(wrapperrors.New("level_test_not_found", nil)))
+	definition := wrapperrors.Define("level_test_internal", http.StatusInternalServerError)
+	assert.Equal(t, wrapperrors.LevelCritical, wrapperrors.GetLevel(definition.FromDefinition(nil)))
+	assert.Equal(t, wrapperrors.LevelCritical, wrapperrors.GetLevel(wrapperrors.Wrapf(definition.FromDefinition(nil), "wrapped")))
+	assert.Equal(t, wrapperrors.LevelWarning, wrapperrors.GetLevel(
+		wrapperrors.New("level_test_not_found", nil).WithLevel(wrapperrors.LevelWarning)))
+	assert.Equal(t, wrapperrors.LevelError, wrapperrors.GetLevel(wrapperrors.New("level_test_other", nil)))
+}
+
+func TestSetDefaultSeverity(t *testing.T) {
+	wrapperrors.SetDefaultSeverity("severity_test_not_found", wrapperrors.SeverityInfo)
+	wrapperrors.SetDefaultSeverity("severity_test_internal", wrapperrors.SeverityError)
+
+	assert.Equal(t, wrapperrors.SeverityInfo, wrapperrors.GetLevel(wrapperrors.New("severity_test_not_found", nil)))
+	assert.Equal(t, wrapperrors.SeverityError, wrapperrors.GetLevel(wrapperrors.New("severity_test_internal", nil)))
+	assert.Equal(t, wrapperrors.SeverityWarning, wrapperrors.GetLevel(
+		wrapperrors.New("severity_test_not_found", nil).WithSeverity(wrapperrors.SeverityWarning)))
+}
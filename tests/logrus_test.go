@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors/logruserrors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestLogrusFields(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	err := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found")
+	expected := logrus.Fields{
+		"code":    "not_found",
+		"status":  http.StatusNotFound,
+		"message": "car has not been found",
+		"cause":   "sql: no rows in result set",
+	}
+	assert.Equal(t, expected, logruserrors.LogrusFields(err))
+}
+
+func TestLogrusFields_PlainError(t *testing.T) {
+	assert.Equal(t, logrus.Fields{"cause": "boom"}, logruserrors.LogrusFields(errors.New("boom")))
+	assert.Equal(t, logrus.Fields{}, logruserrors.LogrusFields(nil))
+}
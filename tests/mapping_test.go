@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+var (
+	errMappedRow   = errors.New("mapped: no rows in result set")
+	errUnmappedRow = errors.New("unmapped: no rows in result set")
+)
+
+func init() {
+	wrapperrors.RegisterMapping(errMappedRow, wrapperrors.Define("not_found", http.StatusNotFound))
+}
+
+func TestWrap_MappedSentinel(t *testing.T) {
+	wrappedError := wrapperrors.Wrap(fmt.Errorf("query: %w", errMappedRow), "lookup")
+	assert.Equal(t, "not_found", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(wrappedError))
+	assert.Equal(t, "lookup", wrapperrors.Message(wrappedError))
+	assert.True(t, errors.Is(wrappedError, errMappedRow))
+}
+
+func TestWrap_MappedSentinelBelowWrapper(t *testing.T) {
+	conflict := wrapperrors.Define("conflict", http.StatusConflict)
+	wrappedError := wrapperrors.Wrap(conflict.FromDefinition(fmt.Errorf("x: %w", errMappedRow)), "msg")
+	assert.Equal(t, "conflict", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusConflict, wrapperrors.GetStatusCode(wrappedError))
+	assert.True(t, errors.Is(wrappedError, errMappedRow))
+}
+
+func TestWrap_UnmappedSentinel(t *testing.T) {
+	wrappedError := wrapperrors.Wrap(errUnmappedRow, "lookup")
+	assert.Equal(t, "unknown_error", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusInternalServerError, wrapperrors.GetStatusCode(wrappedError))
+	assert.True(t, errors.Is(wrappedError, errUnmappedRow))
+	assert.Equal(t, "{\"code\": [\"unknown_error\"], \"status\": [{\"message\": \"Internal Server Error\", \"code\": 500}]}", wrapperrors.UnknownError.String())
+}
+
+func TestNew_MappedSentinel(t *testing.T) {
+	wrappedError := wrapperrors.New("car_not_found", errMappedRow)
+	assert.Equal(t, "car_not_found", wrapperrors.Code(wrappedError))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(wrappedError))
+	assert.Equal(t, "[]", wrapperrors.Status(wrapperrors.New("car_not_found", errUnmappedRow)))
+}
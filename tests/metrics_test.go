@@ -0,0 +1,37 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestStatusCounts(t *testing.T) {
+	wrapperrors.ResetCounts()
+	defer wrapperrors.ResetCounts()
+	notFound := wrapperrors.Define("metrics_not_found", http.StatusNotFound)
+	conflict := wrapperrors.Define("metrics_conflict", http.StatusConflict)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			notFound.FromDefinition(sql.ErrNoRows)
+		}()
+	}
+	wg.Wait()
+	conflict.FromDefinition(nil)
+
+	counts := wrapperrors.StatusCounts()
+	assert.Equal(t, map[int]int64{http.StatusNotFound: 10, http.StatusConflict: 1}, counts)
+
+	counts[http.StatusNotFound] = 0
+	assert.Equal(t, int64(10), wrapperrors.StatusCounts()[http.StatusNotFound])
+
+	wrapperrors.ResetCounts()
+	assert.Empty(t, wrapperrors.StatusCounts())
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+)
+
+type fakeNetError struct {
+	temporary bool
+	timeout   bool
+}
+
+func (e fakeNetError) Error() string   { return "fake network error" }
+func (e fakeNetError) Temporary() bool { return e.temporary }
+func (e fakeNetError) Timeout() bool   { return e.timeout }
+
+var _ net.Error = fakeNetError{}
+
+func TestTemporaryTimeout_Inherited(t *testing.T) {
+	var err error = wrapperrors.New("network_error", fakeNetError{temporary: true, timeout: true})
+	temporary, ok := err.(interface{ Temporary() bool })
+	assert.True(t, ok)
+	assert.True(t, temporary.Temporary())
+	timeout, ok := err.(interface{ Timeout() bool })
+	assert.True(t, ok)
+	assert.True(t, timeout.Timeout())
+}
+
+func TestTemporaryTimeout_InheritedFromNestedWrapper(t *testing.T) {
+	inner := wrapperrors.New("network_error", fakeNetError{temporary: true})
+	var err error = wrapperrors.New("outer_error", inner)
+	assert.True(t, err.(interface{ Temporary() bool }).Temporary())
+	assert.False(t, err.(interface{ Timeout() bool }).Timeout())
+}
+
+func TestTemporaryTimeout_Overridden(t *testing.T) {
+	var err error = wrapperrors.New("network_error", fakeNetError{temporary: true, timeout: true}).
+		WithTemporary(false).
+		WithTimeout(false)
+	assert.False(t, err.(interface{ Temporary() bool }).Temporary())
+	assert.False(t, err.(interface{ Timeout() bool }).Timeout())
+}
+
+func TestTemporaryTimeout_Default(t *testing.T) {
+	var err error = wrapperrors.New("network_error", errors.New("plain")).WithTimeout(true)
+	assert.False(t, err.(interface{ Temporary() bool }).Temporary())
+	assert.True(t, err.(interface{ Timeout() bool }).Timeout())
+}
@@ -0,0 +1,18 @@
+package tests
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOperations(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows).WithOperation("db.Query")
+	middle := wrapperrors.Wrap(fmt.Errorf("scan: %w", inner), "user lookup failed").WithOperation("users.store")
+	outer := wrapperrors.Wrap(middle, "create failed").WithOperation("users.Create")
+	assert.Equal(t, []string{"users.Create", "users.store", "db.Query"}, wrapperrors.Operations(outer))
+	assert.Contains(t, fmt.Sprintf("%+v", outer), "\nop: users.Create > users.store > db.Query")
+	assert.Empty(t, wrapperrors.Operations(sql.ErrNoRows))
+}
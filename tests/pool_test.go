@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestRelease(t *testing.T) {
+	wrapperrors.SetPooling(true)
+	defer wrapperrors.SetPooling(false)
+
+	wrappedError := wrapperrors.New("not_found", sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithStatus(http.StatusNotFound).
+		WithField("id", "abc").
+		WithTraceID("trace")
+	wrapperrors.Release(wrappedError)
+	assert.Equal(t, "", wrappedError.Error())
+	assert.Empty(t, wrapperrors.Fields(wrappedError))
+	assert.Empty(t, wrapperrors.TraceID(wrappedError))
+	assert.Nil(t, wrapperrors.Cause(wrappedError))
+	assert.Equal(t, uint64(0), wrapperrors.Sequence(wrappedError))
+
+	reused := wrapperrors.New("invalid_payload", nil)
+	assert.Equal(t, "code: [invalid_payload]", reused.Error())
+	assert.Empty(t, wrapperrors.Fields(reused))
+	assert.Empty(t, wrapperrors.TraceID(reused))
+}
+
+func TestRelease_Disabled(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", nil)
+	wrapperrors.Release(wrappedError)
+	assert.Equal(t, "code: [not_found]", wrappedError.Error())
+
+	definition := wrapperrors.Define("pool_test_definition", http.StatusNotFound)
+	wrapperrors.SetPooling(true)
+	defer wrapperrors.SetPooling(false)
+	wrapperrors.Release(definition)
+	assert.Equal(t, "pool_test_definition", wrapperrors.Code(definition))
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestPretty(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithStatus(http.StatusNotFound)
+	outer := wrapperrors.New("internal", inner).WithStatus(http.StatusInternalServerError)
+	expected := "code: internal\n" +
+		"status: 500 Internal Server Error\n" +
+		"cause:\n" +
+		"  code: not_found\n" +
+		"  message: car has not been found\n" +
+		"  status: 404 Not Found\n" +
+		"  cause:\n" +
+		"    sql: no rows in result set"
+	assert.Equal(t, expected, outer.Pretty(false))
+}
+
+func TestPretty_Color(t *testing.T) {
+	wrappedError := wrapperrors.New("not_found", nil).
+		WithMessage("car has not been found").
+		WithStatus(http.StatusNotFound)
+	expected := "code: \x1b[31mnot_found\x1b[0m\n" +
+		"message: car has not been found\n" +
+		"status: \x1b[33m404 Not Found\x1b[0m"
+	assert.Equal(t, expected, wrappedError.Pretty(true))
+	assert.NotContains(t, wrappedError.Pretty(false), "\x1b[")
+}
+
+func TestPretty_Cycle(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", first)
+	first.WithCause(second)
+	assert.Equal(t, "code: first\ncause:\n  code: second\n  cause:\n    code: first", first.Pretty(false))
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestToProblem9457(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	problem := wrapperrors.ToProblem9457(notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car abc missing from table car").
+		WithPublicMessage("car has not been found"))
+	assert.Equal(t, "about:blank", problem["type"])
+	assert.Equal(t, "Not Found", problem["title"])
+	assert.Equal(t, http.StatusNotFound, problem["status"])
+	assert.Equal(t, "car has not been found", problem["detail"])
+	assert.Equal(t, []string{"not_found"}, problem["code"])
+	assert.Equal(t, "sql: no rows in result set", problem["cause"])
+}
+
+func TestToProblem9457_Fields(t *testing.T) {
+	problem := wrapperrors.ToProblem9457(wrapperrors.Newf("problem_test_fields", "invalid car").
+		WithField("k", "v").
+		WithField("status", "overwritten").
+		WithField("detail", "overwritten").
+		WithStatus(http.StatusBadRequest))
+	assert.Equal(t, "v", problem["k"])
+	assert.Equal(t, http.StatusBadRequest, problem["status"])
+	assert.Equal(t, "An error occurred.", problem["detail"])
+	assert.Equal(t, "Bad Request", problem["title"])
+}
+
+func TestToProblem9457_PlainError(t *testing.T) {
+	problem := wrapperrors.ToProblem9457(errors.New("boom"))
+	assert.Equal(t, http.StatusInternalServerError, problem["status"])
+	assert.Equal(t, []string{"unknown_error"}, problem["code"])
+	assert.Equal(t, "boom", problem["cause"])
+	assert.Equal(t, "An error occurred.", problem["detail"])
+}
+
+func TestSetTypeURI(t *testing.T) {
+	wrapperrors.SetTypeURI("problem_test_conflict", "https://docs.example.com/errors/conflict")
+	conflict := wrapperrors.Define("problem_test_conflict", http.StatusConflict).FromDefinition(nil)
+	assert.Equal(t, "https://docs.example.com/errors/conflict", wrapperrors.ToProblem9457(conflict)["type"])
+	assert.Equal(t, "https://docs.example.com/errors/conflict", wrapperrors.ToProblem9457(conflict.WithCode("car"))["type"])
+	assert.Equal(t, "about:blank", wrapperrors.ToProblem9457(wrapperrors.Newf("problem_test_other", "other"))["type"])
+}
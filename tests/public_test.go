@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestErrorsAs_PublicWrapper(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	inner := notFound.FromDefinition(sql.ErrNoRows).WithMessage("car has not been found").WithField("id", "abc")
+	err := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", inner))
+
+	var public *wrapperrors.PublicWrapper
+	assert.True(t, errors.As(err, &public))
+	assert.Equal(t, []string{"not_found"}, public.Code())
+	assert.Equal(t, []string{"car has not been found"}, public.Message())
+	assert.Equal(t, []wrapperrors.StatusEntry{{Message: "Not Found", Code: http.StatusNotFound}}, public.Status())
+	assert.Equal(t, []error{sql.ErrNoRows}, public.Causes())
+	assert.Equal(t, map[string]interface{}{"id": "abc"}, public.Fields())
+	assert.Equal(t, inner.Error(), public.Error())
+	assert.True(t, errors.Is(public, sql.ErrNoRows))
+}
+
+func TestAs_ReadOnly(t *testing.T) {
+	inner := wrapperrors.New("not_found", nil).WithMessage("message a")
+	public, ok := wrapperrors.As(fmt.Errorf("wrapped: %w", inner))
+	assert.True(t, ok)
+	messages := public.Message()
+	messages[0] = "changed"
+	assert.Equal(t, []string{"message a"}, public.Message())
+	assert.Equal(t, "message a", wrapperrors.Message(inner))
+}
+
+func TestAs_NotFound(t *testing.T) {
+	public, ok := wrapperrors.As(errors.New("plain"))
+	assert.False(t, ok)
+	assert.Nil(t, public)
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentBuildersAndReaders is meant to be run with -race: it mutates and reads the same
+// wrapper from several goroutines.
+func TestConcurrentBuildersAndReaders(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	shared := notFound.FromDefinition(sql.ErrNoRows)
+	const iterations = 200
+
+	writers := []func(i int){
+		func(i int) { shared.WithMessagef("message %d", i) },
+		func(i int) { shared.WithField(fmt.Sprintf("key%d", i%10), i) },
+		func(i int) { shared.WithStatus(http.StatusGone) },
+		func(i int) { shared.WithCause(errors.New("cause")) },
+		func(i int) { shared.WithCode("code").WithOperation("op").WithTraceID("trace") },
+		func(i int) { shared.WithTemporary(true).WithLevel(wrapperrors.LevelWarning).WithHeader("X-Retry", "1") },
+	}
+	readers := []func(){
+		func() { _ = shared.Error() },
+		func() { _ = shared.String() },
+		func() { _ = shared.Json() },
+		func() { _ = shared.Response() },
+		func() { _ = shared.TreeMap() },
+		func() { _ = shared.Debug() },
+		func() { _ = shared.EncodeJSON(io.Discard) },
+		func() { _, _ = json.Marshal(shared) },
+		func() { _ = fmt.Sprintf("%+v", shared) },
+		func() { _ = shared.Clone().Equal(shared) },
+		func() { _ = errors.Is(shared, notFound) || errors.Is(shared, sql.ErrNoRows) },
+		func() { _ = wrapperrors.SameKind(shared, notFound) || wrapperrors.SameKind(notFound, shared) },
+		func() {
+			_ = wrapperrors.Code(shared) + wrapperrors.Message(shared) + wrapperrors.Status(shared)
+			_ = wrapperrors.Fields(shared)
+			_ = wrapperrors.GetStatusCode(shared)
+			_ = wrapperrors.GetLevel(shared)
+			_ = wrapperrors.Headers(shared)
+			_ = wrapperrors.TraceID(shared)
+			_ = wrapperrors.Operations(shared)
+			_ = wrapperrors.StackTrace(shared)
+		},
+		func() { _ = wrapperrors.Wrapf(shared, "wrapped").Error() },
+		func() { _ = notFound.FromDefinition(shared).Error() },
+	}
+
+	wg := sync.WaitGroup{}
+	for _, write := range writers {
+		wg.Add(1)
+		go func(write func(int)) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				write(i)
+			}
+		}(write)
+	}
+	for _, read := range readers {
+		wg.Add(1)
+		go func(read func()) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				read()
+			}
+		}(read)
+	}
+	wg.Wait()
+}
+
+// TestConcurrentValidationError is meant to be run with -race: it adds and reads field errors of the
+// same validation error from several goroutines.
+func TestConcurrentValidationError(t *testing.T) {
+	shared := wrapperrors.NewValidationError()
+	const iterations = 200
+
+	readers := []func(){
+		func() { _ = shared.Json() },
+		func() { _ = shared.FieldErrors() },
+		func() { _ = shared.HasFieldErrors() },
+		func() { _ = shared.Response() },
+		func() { _ = shared.EncodeJSON(io.Discard) },
+		func() { _, _ = json.Marshal(shared) },
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < iterations; i++ {
+			shared.AddFieldError(fmt.Sprintf("field%d", i%10), "is invalid")
+		}
+	}()
+	for _, read := range readers {
+		wg.Add(1)
+		go func(read func()) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				read()
+			}
+		}(read)
+	}
+	wg.Wait()
+}
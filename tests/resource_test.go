@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResource(t *testing.T) {
+	notFound := wrapperrors.Define("resource_not_found", http.StatusNotFound)
+	err := notFound.FromDefinition(sql.ErrNoRows).WithResource("/cars/123")
+	assert.Equal(t, "/cars/123", wrapperrors.Resource(err))
+	assert.Equal(t, "/cars/123", err.Json()["resource"])
+	assert.Equal(t, "/cars/123", wrapperrors.ToProblem9457(err)["instance"])
+
+	wrapped := wrapperrors.Wrap(err, "car lookup")
+	assert.Equal(t, "/cars/123", wrapperrors.Resource(wrapped))
+	assert.Equal(t, "/cars/123", wrapperrors.ToProblem9457(wrapped)["instance"])
+
+	plain := notFound.FromDefinition(nil)
+	assert.NotContains(t, plain.Json(), "resource")
+	assert.NotContains(t, wrapperrors.ToProblem9457(plain), "instance")
+	assert.Empty(t, wrapperrors.Resource(sql.ErrNoRows))
+}
+
+func TestResource_Serializers(t *testing.T) {
+	err := wrapperrors.New("resource_not_found", nil).
+		WithStatus(http.StatusNotFound).
+		WithHint("check the car ID").
+		WithResource("/cars/123")
+	buff := bytes.Buffer{}
+	assert.NoError(t, err.EncodeJSON(&buff))
+	assert.Contains(t, buff.String(), `"resource":"/cars/123"`)
+	assert.Contains(t, buff.String(), `"hint":"check the car ID"`)
+	assert.Equal(t, "/cars/123", err.Response().Resource)
+
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, err)
+	decoded, decodeErr := wrapperrors.FromHTTPResponse(recorder.Result())
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, "/cars/123", wrapperrors.Resource(decoded))
+}
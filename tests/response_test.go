@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestResponse(t *testing.T) {
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound)
+	response := notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithField("id", "abc").
+		Response()
+	assert.Equal(t, wrapperrors.ErrorResponse{
+		Code:    []string{"not_found"},
+		Message: []string{"car has not been found"},
+		Status:  []wrapperrors.StatusEntry{{Message: "Not Found", Code: http.StatusNotFound}},
+		Cause:   "sql: no rows in result set",
+		Fields:  map[string]interface{}{"id": "abc"},
+	}, response)
+
+	body, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": ["not_found"],
+		"message": ["car has not been found"],
+		"status": [{"message": "Not Found", "code": 404}],
+		"cause": "sql: no rows in result set",
+		"fields": {"id": "abc"}
+	}`, string(body))
+}
+
+func TestResponse_MatchesJson(t *testing.T) {
+	err := wrapperrors.New("testing_error", nil).WithStatus(http.StatusBadRequest)
+	fromResponse, marshalErr := json.Marshal(err.Response())
+	assert.NoError(t, marshalErr)
+	fromJson, marshalErr := json.Marshal(err.Json())
+	assert.NoError(t, marshalErr)
+	assert.JSONEq(t, string(fromJson), string(fromResponse))
+}
@@ -0,0 +1,22 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSafeStringMode(t *testing.T) {
+	wrapperrors.MarkSensitive("safe_test_email")
+	err := wrapperrors.New("invalid_payload", errors.New("user jane@example.com not found")).
+		WithField("safe_test_email", "jane@example.com").
+		WithField("request_id", "abc")
+
+	assert.Contains(t, err.String(), "jane@example.com")
+	assert.Contains(t, err.String(), `"cause"`)
+
+	wrapperrors.SetSafeStringMode(true)
+	defer wrapperrors.SetSafeStringMode(false)
+	assert.Equal(t, `{"code": ["invalid_payload"], "fields": {"request_id":"abc"}}`, err.String())
+}
@@ -0,0 +1,26 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldLog(t *testing.T) {
+	wrapperrors.SetSampleRate("sampled_error", 2)
+	defer wrapperrors.SetSampleRate("sampled_error", 0)
+
+	err := wrapperrors.New("sampled_error", nil)
+	assert.True(t, wrapperrors.ShouldLog(err))
+	assert.True(t, wrapperrors.ShouldLog(err))
+	assert.False(t, wrapperrors.ShouldLog(err))
+}
+
+func TestShouldLog_Unlimited(t *testing.T) {
+	err := wrapperrors.New("unsampled_error", nil)
+	for i := 0; i < 10; i++ {
+		assert.True(t, wrapperrors.ShouldLog(err))
+	}
+	assert.True(t, wrapperrors.ShouldLog(errors.New("plain")))
+}
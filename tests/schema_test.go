@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"encoding/json"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestOpenAPISchema(t *testing.T) {
+	schema := wrapperrors.OpenAPISchema()
+	assert.Equal(t, "object", schema["type"])
+	assert.ElementsMatch(t, []string{"code", "message", "status"}, schema["required"])
+
+	properties := schema["properties"].(map[string]interface{})
+	types := map[string]string{}
+	for name, property := range properties {
+		types[name] = property.(map[string]interface{})["type"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		"code":     "array",
+		"message":  "array",
+		"status":   "array",
+		"cause":    "string",
+		"fields":   "object",
+		"trace_id": "string",
+	}, types)
+
+	data, err := json.Marshal(wrapperrors.New("not_found", nil).
+		WithStatus(http.StatusNotFound).
+		WithField("id", "abc").
+		WithTraceID("trace").
+		WithCause(http.ErrNoCookie))
+	assert.NoError(t, err)
+	var encoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &encoded))
+	for key := range encoded {
+		assert.Contains(t, properties, key)
+	}
+}
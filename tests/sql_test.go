@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string {
+	return "pq: duplicate key value violates unique constraint"
+}
+
+func (e *pgError) SQLState() string {
+	return e.code
+}
+
+func TestFromSQL_UniqueViolation(t *testing.T) {
+	cause := &pgError{code: "23505"}
+	err := wrapperrors.FromSQL(fmt.Errorf("insert user: %w", cause))
+	assert.Equal(t, "conflict", wrapperrors.Code(err))
+	assert.Equal(t, http.StatusConflict, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "23505", wrapperrors.Fields(err)["sqlstate"])
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestFromSQL_NoRows(t *testing.T) {
+	err := wrapperrors.FromSQL(sql.ErrNoRows)
+	assert.Equal(t, "not_found", wrapperrors.Code(err))
+	assert.Equal(t, http.StatusNotFound, wrapperrors.GetStatusCode(err))
+	assert.True(t, errors.Is(err, sql.ErrNoRows))
+}
+
+func TestFromSQL_Unknown(t *testing.T) {
+	err := wrapperrors.FromSQL(&pgError{code: "XX000"})
+	assert.Equal(t, "internal_error", wrapperrors.Code(err))
+	assert.Equal(t, "XX000", wrapperrors.Fields(err)["sqlstate"])
+	assert.Equal(t, "internal_error", wrapperrors.Code(wrapperrors.FromSQL(errors.New("connection refused"))))
+	assert.Nil(t, wrapperrors.FromSQL(nil))
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestStackTrace_Default(t *testing.T) {
+	wrappedError := wrapperrors.New("testing_error", nil)
+	assert.Contains(t, wrapperrors.StackTrace(wrappedError), "tests.TestStackTrace_Default")
+	assert.NotContains(t, wrapperrors.StackTrace(wrappedError), "wrapperrors.newError")
+}
+
+func TestSetStackCaptureForStatusClass(t *testing.T) {
+	wrapperrors.SetStackCaptureForStatusClass("5xx")
+	defer wrapperrors.SetStackCaptureForStatusClass("")
+
+	notFound := wrapperrors.Define("not_found", http.StatusNotFound).FromDefinition(nil)
+	internal := wrapperrors.Define("internal", http.StatusInternalServerError).FromDefinition(nil)
+	deferred := wrapperrors.New("deferred", nil)
+	assert.Empty(t, wrapperrors.StackTrace(notFound))
+	assert.Contains(t, wrapperrors.StackTrace(internal), "tests.TestSetStackCaptureForStatusClass")
+	assert.Empty(t, wrapperrors.StackTrace(deferred))
+	deferred.WithStatus(http.StatusBadGateway)
+	assert.Contains(t, wrapperrors.StackTrace(deferred), "tests.TestSetStackCaptureForStatusClass")
+}
+
+func newStackedError() wrapperrors.ErrorWrapper {
+	return wrapperrors.New("testing_error", nil)
+}
+
+func TestStackTrace_WrapDedup(t *testing.T) {
+	wrappedError := wrapperrors.Wrap(wrapperrors.Wrap(newStackedError(), "first"), "second")
+	formatted := fmt.Sprintf("%+v", wrappedError)
+	assert.True(t, strings.HasPrefix(formatted, wrappedError.Error()+"\n"))
+	assert.Contains(t, formatted, "tests.newStackedError")
+
+	lines := strings.Split(formatted, "\n")[1:]
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(lines); i += 2 {
+		frame := lines[i] + lines[i+1]
+		assert.False(t, seen[frame], "duplicated frame %s", frame)
+		seen[frame] = true
+	}
+	assert.Equal(t, wrappedError.Error(), fmt.Sprintf("%v", wrappedError))
+}
+
+func TestFormat_Verbs(t *testing.T) {
+	wrappedError := wrapperrors.New("testing_error", nil)
+	assert.Equal(t, "code: [testing_error]", fmt.Sprintf("%s", wrappedError))
+	assert.Equal(t, `"code: [testing_error]"`, fmt.Sprintf("%q", wrappedError))
+	assert.Equal(t, "%!d(code: [testing_error])", fmt.Sprintf("%d", wrappedError))
+}
+
+func TestSetStackDepth(t *testing.T) {
+	wrapperrors.SetStackDepth(3)
+	defer wrapperrors.SetStackDepth(0)
+
+	wrappedError := wrapperrors.New("testing_error", nil)
+	assert.LessOrEqual(t, strings.Count(wrapperrors.StackTrace(wrappedError), "\n\t"), 3)
+}
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, wrapperrors.StatusNotFound.Code())
+	assert.Equal(t, "Not Found", wrapperrors.StatusNotFound.Text())
+	assert.Equal(t, "Too Many Requests", wrapperrors.StatusTooManyRequests.Text())
+	assert.Equal(t, "599", wrapperrors.StatusCode(599).Text())
+}
+
+func TestWithStatusCode(t *testing.T) {
+	wrappedError := wrapperrors.New("conflict", nil).WithStatusCode(wrapperrors.StatusConflict)
+	assert.Equal(t, http.StatusConflict, wrapperrors.GetStatusCode(wrappedError))
+	assert.Equal(t, `[{"message": "Conflict", "code": 409}]`, wrapperrors.Status(wrappedError))
+}
+
+func TestIsStatusHelpers(t *testing.T) {
+	helpers := map[int]func(error) bool{
+		http.StatusBadRequest:         wrapperrors.IsBadRequest,
+		http.StatusUnauthorized:       wrapperrors.IsUnauthorized,
+		http.StatusForbidden:          wrapperrors.IsForbidden,
+		http.StatusNotFound:           wrapperrors.IsNotFound,
+		http.StatusConflict:           wrapperrors.IsConflict,
+		http.StatusTooManyRequests:    wrapperrors.IsTooManyRequests,
+		http.StatusServiceUnavailable: wrapperrors.IsServiceUnavailable,
+	}
+	for status, is := range helpers {
+		assert.True(t, is(wrapperrors.New("status_test", nil).WithStatus(status)), "status %d", status)
+		assert.False(t, is(wrapperrors.New("status_test", nil).WithStatus(http.StatusTeapot)), "status %d", status)
+		assert.False(t, is(errors.New("plain")), "status %d", status)
+	}
+	assert.True(t, wrapperrors.IsNotFound(wrapperrors.New("status_test", nil).WithStatus(http.StatusBadRequest).WithStatus(http.StatusNotFound)))
+}
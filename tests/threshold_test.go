@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestOverThreshold(t *testing.T) {
+	current := time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC)
+	wrapperrors.SetClock(func() time.Time { return current })
+	defer wrapperrors.SetClock(nil)
+	wrapperrors.SetThreshold("threshold_test", 2)
+	defer wrapperrors.SetThreshold("threshold_test", 0)
+
+	wrapperrors.New("threshold_test", nil)
+	current = current.Add(20 * time.Second)
+	wrapperrors.New("threshold_test", nil)
+	assert.False(t, wrapperrors.OverThreshold("threshold_test"))
+
+	current = current.Add(20 * time.Second)
+	wrapperrors.New("threshold_test", nil)
+	assert.True(t, wrapperrors.OverThreshold("threshold_test"))
+
+	current = current.Add(30 * time.Second)
+	assert.False(t, wrapperrors.OverThreshold("threshold_test"))
+	assert.False(t, wrapperrors.OverThreshold("threshold_test_other"))
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTraceID_Inherited(t *testing.T) {
+	inner := wrapperrors.New("not_found", nil).WithTraceID("trace-123")
+	outer := wrapperrors.New("internal", errors.New("plain")).WithCause(inner)
+	assert.Equal(t, "trace-123", wrapperrors.TraceID(outer))
+	assert.Equal(t, "trace-123", wrapperrors.TraceID(wrapperrors.Wrap(outer, "wrapped")))
+}
+
+func TestTraceID_Overridden(t *testing.T) {
+	inner := wrapperrors.New("not_found", nil).WithTraceID("trace-123")
+	outer := wrapperrors.New("internal", inner).WithTraceID("trace-456")
+	assert.Equal(t, "trace-456", wrapperrors.TraceID(outer))
+	assert.Equal(t, "", wrapperrors.TraceID(errors.New("plain")))
+}
+
+func TestTraceID_Serialization(t *testing.T) {
+	err := wrapperrors.Flatten(nil).WithTraceID("trace-123")
+	assert.Equal(t, map[string]interface{}{"trace_id": "trace-123"}, err.Json())
+	body, marshalErr := json.Marshal(err.Response())
+	assert.NoError(t, marshalErr)
+	assert.JSONEq(t, `{"trace_id": "trace-123"}`, string(body))
+
+	inherited := wrapperrors.New("internal", wrapperrors.New("not_found", nil).WithTraceID("trace-789"))
+	assert.Equal(t, "trace-789", inherited.Json()["trace_id"])
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"database/sql"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	inner := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	outer := wrapperrors.New("internal", inner).WithMessage("lookup failed")
+	expected := map[string]interface{}{
+		"code":    []string{"internal"},
+		"message": []string{"lookup failed"},
+		"cause": map[string]interface{}{
+			"code":   []string{"not_found"},
+			"status": []map[string]interface{}{{"message": "Not Found", "code": http.StatusNotFound}},
+			"cause":  "sql: no rows in result set",
+		},
+	}
+	assert.Equal(t, expected, outer.TreeMap())
+}
+
+func TestTreeMap_Cycle(t *testing.T) {
+	first := wrapperrors.New("first", nil)
+	second := wrapperrors.New("second", first)
+	first.WithCause(second)
+	expected := map[string]interface{}{
+		"code": []string{"first"},
+		"cause": map[string]interface{}{
+			"code":  []string{"second"},
+			"cause": map[string]interface{}{"code": []string{"first"}},
+		},
+	}
+	assert.Equal(t, expected, first.TreeMap())
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidationError(t *testing.T) {
+	validationError := wrapperrors.NewValidationError().
+		AddFieldError("email", "is required").
+		AddFieldError("name", "is too short").
+		AddFieldError("email", "must be a valid e-mail")
+	assert.Equal(t, http.StatusUnprocessableEntity, wrapperrors.GetStatusCode(validationError))
+	assert.Equal(t, "validation_error", wrapperrors.Code(validationError))
+
+	body, err := json.Marshal(validationError.Json())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"code": ["validation_error"],
+		"status": [{"message": "Unprocessable Entity", "code": 422}],
+		"fields": {"email": ["is required", "must be a valid e-mail"], "name": ["is too short"]}
+	}`, string(body))
+}
+
+func TestValidationError_As(t *testing.T) {
+	err := fmt.Errorf("create car: %w", wrapperrors.NewValidationError().AddFieldError("name", "is required"))
+	var validationError *wrapperrors.ValidationError
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, validationError.FieldErrors())
+}
+
+func TestValidationError_WriteResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	wrapperrors.WriteResponse(recorder, wrapperrors.NewValidationError().AddFieldError("name", "is required"))
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"fields":{"name":["is required"]}`)
+}
+
+func TestValidationError_Builders(t *testing.T) {
+	err := wrapperrors.NewValidationError().
+		AddFieldError("name", "is required").
+		WithMessage("invalid car").
+		WithField("request_id", "abc").
+		WithStatus(http.StatusBadRequest)
+	var validationError *wrapperrors.ValidationError
+	assert.True(t, errors.As(fmt.Errorf("create car: %w", err), &validationError))
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, validationError.FieldErrors())
+	assert.Equal(t, http.StatusBadRequest, wrapperrors.GetStatusCode(err))
+
+	cloned, ok := err.Clone().(*wrapperrors.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, cloned.FieldErrors())
+	detached, ok := err.Detach().(*wrapperrors.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, detached.FieldErrors())
+}
+
+func TestValidationError_Serializers(t *testing.T) {
+	validationError := wrapperrors.NewValidationError().AddFieldError("name", "is required")
+	expected := map[string]interface{}{"name": []string{"is required"}}
+
+	assert.Equal(t, expected, validationError.Response().Fields)
+
+	var buf bytes.Buffer
+	assert.NoError(t, validationError.EncodeJSON(&buf))
+	assert.Contains(t, buf.String(), `"fields":{"name":["is required"]}`)
+
+	body, err := json.Marshal(validationError)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"fields":{"name":["is required"]}`)
+	decoded := &wrapperrors.ValidationError{}
+	assert.NoError(t, json.Unmarshal(body, decoded))
+	assert.Equal(t, map[string][]string{"name": {"is required"}}, decoded.FieldErrors())
+	assert.Equal(t, "validation_error", wrapperrors.Code(decoded))
+}
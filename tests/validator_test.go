@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors/validatorerrors"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type signUp struct {
+	Name  string `validate:"required"`
+	Email string `validate:"required,email"`
+	Age   int    `validate:"gte=18"`
+}
+
+func TestFromValidator(t *testing.T) {
+	err := validatorerrors.FromValidator(validator.New().Struct(signUp{Email: "not an email", Age: 18}))
+	assert.Equal(t, http.StatusUnprocessableEntity, wrapperrors.GetStatusCode(err))
+	assert.Equal(t, "validation_error", wrapperrors.Code(err))
+
+	var validationError *wrapperrors.ValidationError
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, map[string][]string{
+		"Name":  {"is required"},
+		"Email": {"must be a valid email address"},
+	}, validationError.FieldErrors())
+
+	err = validatorerrors.FromValidator(validator.New().Struct(signUp{Name: "Ana", Email: "ana@example.com", Age: 17}))
+	assert.True(t, errors.As(err, &validationError))
+	assert.Equal(t, map[string][]string{"Age": {"must be greater than or equal to 18"}}, validationError.FieldErrors())
+}
+
+func TestFromValidator_OtherErrors(t *testing.T) {
+	cause := errors.New("boom")
+	err := validatorerrors.FromValidator(cause)
+	assert.Equal(t, "internal_error", wrapperrors.Code(err))
+	assert.True(t, errors.Is(err, cause))
+	assert.Nil(t, validatorerrors.FromValidator(nil))
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestView(t *testing.T) {
+	notFound := wrapperrors.Define("view_not_found", http.StatusNotFound)
+	wrappedError := notFound.FromDefinition(sql.ErrNoRows).
+		WithMessage("car has not been found").
+		WithField("id", "abc")
+	view := wrappedError.View()
+	assert.Equal(t, wrappedError.Error(), view.Error())
+	assert.Equal(t, wrappedError.String(), view.String())
+	assert.Equal(t, wrappedError.Json(), view.Json())
+	assert.Equal(t, "view_not_found", view.Code())
+	assert.Equal(t, "car has not been found", view.Message())
+	assert.Equal(t, http.StatusNotFound, view.StatusCode())
+	assert.Equal(t, map[string]interface{}{"id": "abc"}, view.Fields())
+	assert.True(t, errors.Is(view, sql.ErrNoRows))
+	assert.True(t, errors.Is(view, notFound))
+
+	wrappedError.WithStatus(http.StatusGone)
+	assert.Equal(t, http.StatusGone, view.StatusCode())
+}
+
+func TestView_ReadOnly(t *testing.T) {
+	wrappedError := wrapperrors.New("view_not_found", sql.ErrNoRows).WithField("id", "abc")
+	view := wrappedError.View()
+	_, ok := view.(wrapperrors.ErrorWrapper)
+	assert.False(t, ok)
+	var target wrapperrors.ErrorWrapper
+	assert.False(t, errors.As(view, &target))
+
+	view.Fields()["id"] = "xyz"
+	view.Json()["code"] = "changed"
+	assert.Equal(t, "abc", wrapperrors.Fields(wrappedError)["id"])
+	assert.Equal(t, "view_not_found", wrapperrors.Code(wrappedError))
+}
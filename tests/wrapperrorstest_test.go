@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors/wrapperrorstest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+type recordingT struct {
+	failures []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertCode(t *testing.T) {
+	err := wrapperrors.New("not_found", sql.ErrNoRows)
+	recorder := &recordingT{}
+	assert.True(t, wrapperrorstest.AssertCode(recorder, err, "not_found"))
+	assert.Empty(t, recorder.failures)
+
+	assert.False(t, wrapperrorstest.AssertCode(recorder, err, "conflict"))
+	assert.Len(t, recorder.failures, 1)
+	assert.Contains(t, recorder.failures[0], `expected error with code "conflict"`)
+	assert.Contains(t, recorder.failures[0], "not_found")
+}
+
+func TestAssertStatus(t *testing.T) {
+	err := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	recorder := &recordingT{}
+	assert.True(t, wrapperrorstest.AssertStatus(recorder, err, http.StatusNotFound))
+	assert.Empty(t, recorder.failures)
+
+	assert.False(t, wrapperrorstest.AssertStatus(recorder, err, http.StatusConflict))
+	assert.Len(t, recorder.failures, 1)
+	assert.Contains(t, recorder.failures[0], "expected error with status 409, got status 404")
+}
+
+func TestAssertIs(t *testing.T) {
+	notFound := wrapperrors.Define("wrapperrorstest_not_found", http.StatusNotFound)
+	err := wrapperrors.Wrap(notFound.FromDefinition(sql.ErrNoRows), "car lookup")
+	recorder := &recordingT{}
+	assert.True(t, wrapperrorstest.AssertIs(recorder, err, notFound))
+	assert.Empty(t, recorder.failures)
+
+	assert.False(t, wrapperrorstest.AssertIs(recorder, sql.ErrNoRows, notFound))
+	assert.Len(t, recorder.failures, 1)
+	assert.Contains(t, recorder.failures[0], "non-wrapper error sql: no rows in result set")
+}
+
+func TestAssertEqual(t *testing.T) {
+	expected := wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)
+	recorder := &recordingT{}
+	assert.True(t, wrapperrorstest.AssertEqual(recorder, expected, wrapperrors.New("not_found", sql.ErrNoRows).WithStatus(http.StatusNotFound)))
+	assert.Empty(t, recorder.failures)
+
+	assert.False(t, wrapperrorstest.AssertEqual(recorder, expected, nil))
+	assert.False(t, wrapperrorstest.AssertEqual(recorder, expected, wrapperrors.New("conflict", sql.ErrNoRows)))
+	assert.Len(t, recorder.failures, 2)
+	assert.Contains(t, recorder.failures[0], "got nil error")
+}
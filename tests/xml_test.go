@@ -0,0 +1,28 @@
+package tests
+
+import (
+	"encoding/xml"
+	"errors"
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestMarshalXML(t *testing.T) {
+	expected := "<error><code>not_found</code><message>message a</message><message>message b</message>" +
+		"<status code=\"404\">Not Found</status><cause>testing &lt;error&gt;</cause></error>"
+	wrappedError := wrapperrors.New("not_found", errors.New("testing <error>")).
+		WithStatus(http.StatusNotFound).
+		WithMessage("message a").
+		WithMessage("message b")
+	out, err := xml.Marshal(wrappedError)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(out))
+}
+
+func TestMarshalXML_Empty(t *testing.T) {
+	out, err := xml.Marshal(wrapperrors.New("testing_error", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "<error><code>testing_error</code></error>", string(out))
+}
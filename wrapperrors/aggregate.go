@@ -0,0 +1,160 @@
+package wrapperrors
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	statusPrecedence   []int
+	statusPrecedenceMu sync.RWMutex
+)
+
+// Append adds the given errors as causes of e and returns the resulting aggregate. When e is already an
+// aggregate, as reported by Errors, the errors are added to it in place, as the builders do, and e is
+// returned; otherwise a new AggregateError is created holding e, unless nil, as its first cause. Nil
+// errors are skipped, and nil is returned when e and all the given errors are nil, so that
+// "return Append(nil, errs...)" only fails when one of errs did. Definitions created by Define are
+// cloned instead of being modified.
+//
+// The fields carried along the chain of each appended error are merged into the aggregate. When a key
+// is already set with a different value, the values are collected into a []interface{} in the order
+// they were appended, so no value is dropped; equal values are kept once.
+func Append(e error, errs ...error) ErrorWrapper {
+	if e == nil && !anyError(errs) {
+		return nil
+	}
+	wp, ok := asWrapper(e)
+	if ok && wp.isAggregate() {
+		wp = wp.mutable()
+	} else {
+		wp, _ = asWrapper(AggregateError.FromDefinition(e))
+		wp.mergeFields(AllFields(e))
+	}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		fields := AllFields(err)
+		wp.Lock()
+		wp.causes = wrapCause(err, wp)
+		wp.mergeFields(fields)
+		wp.Unlock()
+	}
+	return wp
+}
+
+// anyError reports whether any of the given errors is not nil.
+func anyError(errs []error) bool {
+	for _, err := range errs {
+		if err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeFields adds the given fields to the error, collecting the values of conflicting keys.
+func (e *wrapper) mergeFields(fields map[string]interface{}) {
+	for key, value := range fields {
+		existing, exists := e.fields[key]
+		if !exists {
+			e.fields = wrapField(key, value, e)
+			continue
+		}
+		values, collected := existing.([]interface{})
+		if !collected {
+			values = []interface{}{existing}
+		}
+		if !containsValue(values, value) {
+			e.fields[key] = append(values, value)
+		}
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the errors held by a given aggregate, flattening nested aggregates. An aggregate is
+// an AggregateError or a wrapper with several causes; any other non-nil error is returned on its own.
+func Errors(e error) []error {
+	if e == nil {
+		return nil
+	}
+	wp, ok := asWrapper(e)
+	if !ok || !wp.isAggregate() {
+		return []error{e}
+	}
+	causes := wp.Unwrap()
+	errs := make([]error, 0, len(causes))
+	for _, cause := range causes {
+		errs = append(errs, Errors(cause)...)
+	}
+	return errs
+}
+
+// Count returns how many errors are held by a given error, as returned by Errors.
+func Count(e error) int {
+	return len(Errors(e))
+}
+
+func (e *wrapper) isAggregate() bool {
+	return len(e.Unwrap()) > 1 || HasCode(e, Code(AggregateError))
+}
+
+// SetStatusPrecedence sets which statuses ResolveStatus prefers among statuses of the same class,
+// most preferred first. Statuses not listed rank below the listed ones.
+func SetStatusPrecedence(statuses ...int) {
+	statusPrecedenceMu.Lock()
+	defer statusPrecedenceMu.Unlock()
+	statusPrecedence = append([]int(nil), statuses...)
+}
+
+// ResolveStatus returns the most severe status of the errors held by a given aggregate, as returned by
+// Errors. 5xx statuses are more severe than 4xx ones, and within a class the precedence set with
+// SetStatusPrecedence applies before the order of the errors. Errors that are not aggregates resolve
+// to their own status code.
+func ResolveStatus(e error) int {
+	wp, ok := asWrapper(e)
+	if !ok || !wp.isAggregate() {
+		return GetStatusCode(e)
+	}
+	resolved, found := 0, false
+	for _, err := range Errors(wp) {
+		if _, ok := asWrapper(err); !ok {
+			continue
+		}
+		status := GetStatusCode(err)
+		if !found || moreSevere(status, resolved) {
+			resolved, found = status, true
+		}
+	}
+	if !found {
+		return GetStatusCode(e)
+	}
+	return resolved
+}
+
+func moreSevere(status, than int) bool {
+	if status/100 != than/100 {
+		return status/100 > than/100
+	}
+	return statusRank(status) < statusRank(than)
+}
+
+func statusRank(status int) int {
+	statusPrecedenceMu.RLock()
+	defer statusPrecedenceMu.RUnlock()
+	for i, s := range statusPrecedence {
+		if s == status {
+			return i
+		}
+	}
+	return len(statusPrecedence)
+}
@@ -0,0 +1,99 @@
+package wrapperrors
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// BatchResult collects the outcome of each item processed by a bulk endpoint. The zero value is ready
+// to use and it is safe for concurrent use.
+type BatchResult struct {
+	mu    sync.Mutex
+	items map[int]error
+}
+
+type batchItem struct {
+	Index  int                    `json:"index"`
+	Status int                    `json:"status"`
+	Error  map[string]interface{} `json:"error,omitempty"`
+}
+
+// AddSuccess records that the item at the given index succeeded.
+func (b *BatchResult) AddSuccess(index int) {
+	b.add(index, nil)
+}
+
+// AddError records that the item at the given index failed with the given error. A nil error records a
+// success.
+func (b *BatchResult) AddError(index int, err error) {
+	b.add(index, err)
+}
+
+func (b *BatchResult) add(index int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.items == nil {
+		b.items = make(map[int]error)
+	}
+	b.items[index] = err
+}
+
+// Status returns the overall status of the batch: 200 when every item succeeded, 207 Multi-Status when
+// some succeeded and some failed, and the most severe status of the errors, as with ResolveStatus, when
+// every item failed, the first item in index order winning among equally severe statuses.
+func (b *BatchResult) Status() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	failed, resolved := 0, 0
+	for _, index := range b.indexes() {
+		err := b.items[index]
+		if err == nil {
+			continue
+		}
+		status := GetStatusCode(err)
+		if failed == 0 || moreSevere(status, resolved) {
+			resolved = status
+		}
+		failed++
+	}
+	switch {
+	case failed == 0:
+		return http.StatusOK
+	case failed < len(b.items):
+		return http.StatusMultiStatus
+	}
+	return resolved
+}
+
+// MarshalJSON encodes the batch as an array holding the index and status of each item, ordered by
+// index. Failed items also hold their error, with the same body WriteResponse writes.
+func (b *BatchResult) MarshalJSON() ([]byte, error) {
+	b.mu.Lock()
+	indexes := b.indexes()
+	items := make([]batchItem, len(indexes))
+	for i, index := range indexes {
+		items[i] = batchItem{Index: index, Status: http.StatusOK}
+		if err := b.items[index]; err != nil {
+			wp, ok := asWrapper(err)
+			if !ok {
+				wp = unknownError(err)
+			}
+			items[i].Status = GetStatusCode(wp)
+			items[i].Error = responseBody(err, wp)
+		}
+	}
+	b.mu.Unlock()
+	return json.Marshal(items)
+}
+
+// indexes returns the indexes of the items in ascending order. The batch must be locked.
+func (b *BatchResult) indexes() []int {
+	indexes := make([]int, 0, len(b.items))
+	for index := range b.items {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
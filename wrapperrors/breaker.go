@@ -0,0 +1,32 @@
+package wrapperrors
+
+func (e *wrapper) WithBreakerTrip(trip bool) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithBreakerTrip(trip) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.breakerTrip = &trip
+	return e
+}
+
+// ShouldTripBreaker reports whether a given error should trip a circuit breaker. Unless set with
+// WithBreakerTrip, the value is inherited from the first error in the cause chain setting it.
+func ShouldTripBreaker(e error) bool {
+	trip := false
+	visitChain(e, func(err error) bool {
+		wp, ok := asWrapper(err)
+		if !ok {
+			return true
+		}
+		unlock := wp.rlock()
+		breakerTrip := wp.breakerTrip
+		unlock()
+		if breakerTrip == nil {
+			return true
+		}
+		trip = *breakerTrip
+		return false
+	})
+	return trip
+}
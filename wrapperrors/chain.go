@@ -0,0 +1,201 @@
+package wrapperrors
+
+import (
+	"iter"
+	"sync"
+)
+
+// HasCode reports whether the given error carries the given code, without looking at its causes.
+func HasCode(e error, code string) bool {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return false
+	}
+	defer wp.rlock()()
+	for _, c := range wp.code {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainHasCode reports whether the given error or any error in its cause chain carries the given code.
+func ChainHasCode(e error, code string) bool {
+	found := false
+	visitChain(e, func(err error) bool {
+		found = HasCode(err, code)
+		return !found
+	})
+	return found
+}
+
+// AllFields merges the fields of every error in the cause chain of a given error. When several
+// errors carry the same key, the outermost value wins.
+func AllFields(e error) map[string]interface{} {
+	fields := make(map[string]interface{})
+	visitChain(e, func(err error) bool {
+		if wp, ok := asWrapper(err); ok {
+			defer wp.rlock()()
+			for key, value := range wp.fields {
+				if _, exists := fields[key]; !exists {
+					fields[key] = value
+				}
+			}
+		}
+		return true
+	})
+	return fields
+}
+
+// Flatten collapses the whole cause chain of a given error into a single wrapper holding every code,
+// message, status, field and leaf cause found, outermost first. Duplicated codes are kept once and
+// outer fields win over inner ones.
+func Flatten(e error) ErrorWrapper {
+	flat := &wrapper{
+		sequence: nextSequence(),
+		RWMutex:  &sync.RWMutex{},
+	}
+	seenCodes := make(map[string]bool)
+	visitChain(e, func(err error) bool {
+		wp, ok := asWrapper(err)
+		if !ok {
+			if len(unwrapAll(err)) == 0 {
+				flat.causes = append(flat.causes, err)
+			}
+			return true
+		}
+		defer wp.rlock()()
+		for _, code := range wp.code {
+			if !seenCodes[code] {
+				seenCodes[code] = true
+				flat.code = append(flat.code, code)
+			}
+		}
+		flat.message = append(flat.message, wp.message...)
+		flat.status = append(flat.status, wp.status...)
+		for key, value := range wp.fields {
+			if _, exists := flat.fields[key]; !exists {
+				flat.fields = wrapField(key, value, flat)
+			}
+		}
+		if flat.stack == nil {
+			flat.stack = wp.stack
+		}
+		return true
+	})
+	return flat
+}
+
+// Chain iterates over the given error and every error in its cause chain, from outermost to innermost.
+// Wrappers already visited are skipped, so cyclic chains terminate.
+func Chain(e error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		visitChain(e, yield)
+	}
+}
+
+// Walk traverses the given error and its whole cause tree depth first, calling fn with the depth of
+// each error, zero for the given one. When fn returns false the causes of that error are skipped.
+// Wrappers already visited are skipped, so cyclic chains terminate.
+func Walk(e error, fn func(depth int, err error) bool) {
+	walk(e, 0, fn, make(map[*wrapper]bool))
+}
+
+func walk(e error, depth int, fn func(depth int, err error) bool, seen map[*wrapper]bool) {
+	if e == nil {
+		return
+	}
+	if wp, ok := e.(*wrapper); ok {
+		if seen[wp] {
+			return
+		}
+		seen[wp] = true
+	}
+	if !fn(depth, e) {
+		return
+	}
+	for _, cause := range unwrapAll(e) {
+		walk(cause, depth+1, fn, seen)
+	}
+}
+
+// CauseAs returns the first error of type T in the cause chain of the given error, depth first, including
+// every cause of errors holding several. Unlike errors.As, the given error itself is not considered.
+func CauseAs[T error](e error) (T, bool) {
+	var found T
+	ok := false
+	seen := make(map[*wrapper]bool)
+	if wp, isWrapper := e.(*wrapper); isWrapper {
+		seen[wp] = true
+	}
+	for _, cause := range unwrapAll(e) {
+		visitChainSeen(cause, func(err error) bool {
+			found, ok = err.(T)
+			return !ok
+		}, seen)
+		if ok {
+			break
+		}
+	}
+	return found, ok
+}
+
+// inheritString returns the value read by get from the first wrapper in the cause chain of the error,
+// excluding the error itself, for which it is not empty.
+func (e wrapper) inheritString(get func(wp *wrapper) string) string {
+	value := ""
+	for _, cause := range e.causes {
+		visitChain(cause, func(err error) bool {
+			if wp, ok := asWrapper(err); ok {
+				unlock := wp.rlock()
+				value = get(wp)
+				unlock()
+			}
+			return value == ""
+		})
+		if value != "" {
+			break
+		}
+	}
+	return value
+}
+
+// visitChain calls fn for the given error and every error in its cause chain, depth first, until fn
+// returns false. Wrappers already visited are skipped, so cyclic chains terminate.
+func visitChain(e error, fn func(err error) bool) bool {
+	return visitChainSeen(e, fn, make(map[*wrapper]bool))
+}
+
+func visitChainSeen(e error, fn func(err error) bool, seen map[*wrapper]bool) bool {
+	if e == nil {
+		return true
+	}
+	if wp, ok := e.(*wrapper); ok {
+		if seen[wp] {
+			return true
+		}
+		seen[wp] = true
+	}
+	if !fn(e) {
+		return false
+	}
+	for _, cause := range unwrapAll(e) {
+		if !visitChainSeen(cause, fn, seen) {
+			return false
+		}
+	}
+	return true
+}
+
+func unwrapAll(e error) []error {
+	switch err := e.(type) {
+	case interface{ Unwrap() []error }:
+		return err.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := err.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package wrapperrors
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	clock   = time.Now
+	clockMu sync.RWMutex
+)
+
+// SetClock sets the function used to read the current time by sample rates and thresholds, e.g. to
+// simulate the passing of time in tests. Passing nil restores time.Now.
+func SetClock(now func() time.Time) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if now == nil {
+		now = time.Now
+	}
+	clock = now
+}
+
+func now() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock()
+}
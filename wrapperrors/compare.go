@@ -0,0 +1,58 @@
+package wrapperrors
+
+import "github.com/google/go-cmp/cmp"
+
+// Comparer returns a go-cmp option comparing wrappers by their code, message, status and cause
+// content, ignoring the mutex and other bookkeeping such as stack traces and sequence numbers.
+func Comparer() cmp.Option {
+	return cmp.Comparer(func(a, b *wrapper) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.clone().equal(b.clone())
+	})
+}
+
+// Equal reports whether the error has the same code, message, status and cause as other, in the same
+// order. Causes are compared by their Error text.
+func (e *wrapper) Equal(other ErrorWrapper) bool {
+	wp, ok := asWrapper(other)
+	if e == nil || !ok {
+		return e == nil && !ok
+	}
+	snapshot := wp.clone()
+	defer e.rlock()()
+	return e.equal(snapshot)
+}
+
+func (e wrapper) equal(other *wrapper) bool {
+	if !equalStrings(e.code, other.code) || !equalStrings(e.message, other.message) {
+		return false
+	}
+	if len(e.status) != len(other.status) || len(e.causes) != len(other.causes) {
+		return false
+	}
+	for i := range e.status {
+		if e.status[i] != other.status[i] {
+			return false
+		}
+	}
+	for i := range e.causes {
+		if e.causes[i].Error() != other.causes[i].Error() {
+			return false
+		}
+	}
+	return true
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
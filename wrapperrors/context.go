@@ -0,0 +1,34 @@
+package wrapperrors
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	contextExtractors   []func(context.Context) map[string]interface{}
+	contextExtractorsMu sync.RWMutex
+)
+
+// RegisterContextExtractor registers a function that extracts metadata fields from a context.
+// Extractors run in registration order whenever an error is created with FromContext.
+func RegisterContextExtractor(extractor func(context.Context) map[string]interface{}) {
+	contextExtractorsMu.Lock()
+	defer contextExtractorsMu.Unlock()
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// FromContext creates a new error with the given code whose fields are populated by the registered
+// context extractors.
+func FromContext(ctx context.Context, code string) ErrorWrapper {
+	wp := newError(code, nil)
+	contextExtractorsMu.RLock()
+	extractors := contextExtractors
+	contextExtractorsMu.RUnlock()
+	for _, extractor := range extractors {
+		for key, value := range extractor(ctx) {
+			wp.WithField(key, value)
+		}
+	}
+	return created(wp)
+}
@@ -0,0 +1,54 @@
+package wrapperrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// statusClientClosedRequest is the non-standard status used when the client canceled the request.
+const statusClientClosedRequest = 499
+
+// WithDeadline records the deadline the failed operation had to meet in the "deadline" field.
+func (e *wrapper) WithDeadline(deadline time.Time) ErrorWrapper {
+	return e.WithField("deadline", deadline)
+}
+
+// detectDeadline adds a 504 status when the given cause is a context.DeadlineExceeded and a 499
+// status when it is a context.Canceled, recording "exceeded" or "canceled" in the "deadline_reason"
+// field unless already set. The error must not be shared yet.
+func (e *wrapper) detectDeadline(cause error) {
+	if status, reason, ok := deadlineCause(cause); ok {
+		e.applyDeadline(status, reason)
+	}
+}
+
+// deadlineCause reports the status and reason matching a deadline or cancellation cause.
+func deadlineCause(cause error) (statusCode, string, bool) {
+	switch {
+	case cause == nil:
+		return statusCode{}, "", false
+	case errors.Is(cause, context.DeadlineExceeded):
+		status := statusCode{
+			message: http.StatusText(http.StatusGatewayTimeout),
+			code:    http.StatusGatewayTimeout,
+		}
+		return status, "exceeded", true
+	case errors.Is(cause, context.Canceled):
+		status := statusCode{message: "Client Closed Request", code: statusClientClosedRequest}
+		return status, "canceled", true
+	}
+	return statusCode{}, "", false
+}
+
+// applyDeadline adds the given status unless it is already the last one and records the reason. The
+// error must be locked or not shared yet.
+func (e *wrapper) applyDeadline(status statusCode, reason string) {
+	if n := len(e.status); n == 0 || e.status[n-1].code != status.code {
+		e.status = append(e.status, status)
+	}
+	if _, exists := e.fields["deadline_reason"]; !exists {
+		e.fields = wrapField("deadline_reason", reason, e)
+	}
+}
@@ -0,0 +1,77 @@
+package wrapperrors
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	debugExtras   func() map[string]string
+	debugExtrasMu sync.RWMutex
+)
+
+// SetDebugExtras sets a hook whose entries are appended to every Debug output, e.g. the build version
+// of the application. Passing nil removes it.
+func SetDebugExtras(extras func() map[string]string) {
+	debugExtrasMu.Lock()
+	defer debugExtrasMu.Unlock()
+	debugExtras = extras
+}
+
+// WithQuery attaches the failing SQL query and its arguments to the error. They are debug-only and
+// are only rendered by Debug, never by Error, String or Json.
+func (e *wrapper) WithQuery(query string, args ...interface{}) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithQuery(query, args...) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.query = query
+	e.queryArgs = append([]interface{}(nil), args...)
+	return e
+}
+
+// Debug returns a self-contained report of the error for bug reports: its String representation
+// followed by its debug-only information, the process PID, hostname and goroutine count, the entries of
+// the SetDebugExtras hook and the captured stack trace, one entry per line.
+func (e *wrapper) Debug() string {
+	if e == nil {
+		return ""
+	}
+	lines := []string{e.String()}
+	unlock := e.rlock()
+	if e.query != "" {
+		lines = append(lines, fmt.Sprintf("query: %s", e.query))
+		lines = append(lines, fmt.Sprintf("args: %v", e.queryArgs))
+	}
+	unlock()
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	lines = append(lines, fmt.Sprintf("pid: %d", os.Getpid()))
+	lines = append(lines, fmt.Sprintf("hostname: %s", hostname))
+	lines = append(lines, fmt.Sprintf("goroutines: %d", runtime.NumGoroutine()))
+	debugExtrasMu.RLock()
+	extras := debugExtras
+	debugExtrasMu.RUnlock()
+	if extras != nil {
+		values := extras()
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", key, values[key]))
+		}
+	}
+	if trace := StackTrace(e); trace != "" {
+		lines = append(lines, fmt.Sprintf("stack:\n%s", strings.TrimSuffix(trace, "\n")))
+	}
+	return strings.Join(lines[:], "\n")
+}
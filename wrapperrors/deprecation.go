@@ -0,0 +1,42 @@
+package wrapperrors
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	deprecationWarnings int32
+
+	// deprecationOnces holds a *sync.Once per deprecated function and call site.
+	deprecationOnces sync.Map
+)
+
+// SetDeprecationWarnings toggles warnings about deprecated functions such as New and Wrap. When
+// enabled, a warning is written to the standard logger the first time each call site uses one.
+func SetDeprecationWarnings(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&deprecationWarnings, mode)
+}
+
+// warnDeprecated warns once per call site of the deprecated function calling it.
+func warnDeprecated(name, replacement string) {
+	if atomic.LoadInt32(&deprecationWarnings) == 0 {
+		return
+	}
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return
+	}
+	site := fmt.Sprintf("%s:%d", file, line)
+	once, _ := deprecationOnces.LoadOrStore(name+" "+site, &sync.Once{})
+	once.(*sync.Once).Do(func() {
+		log.Printf("wrapperrors: %s is deprecated, use %s instead (called from %s)", name, replacement, site)
+	})
+}
@@ -0,0 +1,23 @@
+// Package echoerrors provides Echo framework integration for wrapperrors.
+package echoerrors
+
+import (
+	"errors"
+
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/labstack/echo/v4"
+)
+
+// ToEchoError converts the first wrapper found in the chain of a given error into an *echo.HTTPError
+// using its status code and the body returned by wrapperrors.ResponseBody as message. Clients only get
+// the public messages, plus the causes and fields when enabled with wrapperrors.SetExposeInternals.
+// Errors not created by wrapperrors become 500s. The original error is kept as the internal error.
+func ToEchoError(e error) *echo.HTTPError {
+	var wp wrapperrors.ErrorWrapper
+	if !errors.As(e, &wp) {
+		wp = wrapperrors.UnknownError.FromDefinition(e)
+	}
+	httpError := echo.NewHTTPError(wrapperrors.GetStatusCode(wp), wrapperrors.ResponseBody(wp))
+	httpError.Internal = e
+	return httpError
+}
@@ -0,0 +1,102 @@
+package wrapperrors
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// EncodeJSON streams the client JSON representation of the error to the given writer. The output is the
+// same as the body returned by ResponseBody, holding only public messages and, when enabled with
+// SetExposeInternals, the causes and fields. Causes are encoded one by one instead of building the whole
+// map first, which keeps memory low for aggregates holding many errors.
+func (e *wrapper) EncodeJSON(w io.Writer) error {
+	return e.encodeJSON(w, nil)
+}
+
+// encodeJSON streams the error as EncodeJSON does. When fieldErrors is not nil, it is always written as
+// fields instead of the metadata fields.
+func (e *wrapper) encodeJSON(w io.Writer, fieldErrors map[string][]string) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	members := 0
+	member := func(key string) {
+		if members > 0 {
+			bw.WriteString(",")
+		}
+		members++
+		bw.WriteString(`"` + key + `":`)
+	}
+	bw.WriteString("{")
+	if e != nil {
+		expose := exposingInternals()
+		defer e.rlock()()
+		if len(e.code) > 0 {
+			member("code")
+			if err := enc.Encode(e.code); err != nil {
+				return err
+			}
+		}
+		member("message")
+		if err := enc.Encode(e.publicMessages()); err != nil {
+			return err
+		}
+		if len(e.status) > 0 {
+			member("status")
+			status := make([]StatusEntry, len(e.status))
+			for i, v := range e.status {
+				status[i] = StatusEntry{Message: v.message, Code: v.code}
+			}
+			if err := enc.Encode(status); err != nil {
+				return err
+			}
+		}
+		if expose && len(e.causes) > 0 {
+			member("cause")
+			bw.WriteString(`"`)
+			sep := getSeparator()
+			for i, cause := range e.causes {
+				if i > 0 {
+					bw.WriteString(sep)
+				}
+				quoted, err := json.Marshal(cause.Error())
+				if err != nil {
+					return err
+				}
+				bw.Write(quoted[1 : len(quoted)-1])
+			}
+			bw.WriteString(`"`)
+		}
+		if fieldErrors != nil {
+			member("fields")
+			if err := enc.Encode(fieldErrors); err != nil {
+				return err
+			}
+		} else if expose && len(e.fields) > 0 {
+			member("fields")
+			if err := enc.Encode(e.fields); err != nil {
+				return err
+			}
+		}
+		if traceID := e.resolveTraceID(); traceID != "" {
+			member("trace_id")
+			if err := enc.Encode(traceID); err != nil {
+				return err
+			}
+		}
+		if hint := e.resolveHint(); hint != "" {
+			member("hint")
+			if err := enc.Encode(hint); err != nil {
+				return err
+			}
+		}
+		if resource := e.resolveResource(); resource != "" {
+			member("resource")
+			if err := enc.Encode(resource); err != nil {
+				return err
+			}
+		}
+	}
+	bw.WriteString("}")
+	return bw.Flush()
+}
@@ -5,35 +5,102 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"net/http"
-	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
-	InternalError = Define("internal_error", http.StatusInternalServerError)
-	UnknownError  = Define("unknown_error", http.StatusInternalServerError)
+	InternalError  = Define("internal_error", http.StatusInternalServerError)
+	UnknownError   = Define("unknown_error", http.StatusInternalServerError)
+	AggregateError = Define("aggregate_error", http.StatusInternalServerError)
+)
+
+const defaultPublicMessage = "An error occurred."
+
+var (
+	sequence      uint64
+	defaultStatus int64 = http.StatusInternalServerError
+
+	statusTextResolvers   []func(int) (string, bool)
+	statusTextResolversMu sync.RWMutex
 )
 
 type ErrorWrapper interface {
 	Error() string
+	PublicError() string
 	String() string
 	Json() map[string]interface{}
+	TreeMap() map[string]interface{}
+	Response() ErrorResponse
+	EncodeJSON(w io.Writer) error
+	Debug() string
+	Pretty(color bool) string
+	View() ErrorView
 	WithMessage(message string) ErrorWrapper
+	WithMessagef(format string, args ...interface{}) ErrorWrapper
+	WithPublicMessage(message string) ErrorWrapper
+	WithCode(code string) ErrorWrapper
 	WithStatus(status int) ErrorWrapper
+	WithStatusText(status int, text string) ErrorWrapper
+	WithStatusCode(status StatusCode) ErrorWrapper
 	WithCause(err error) ErrorWrapper
+	WithCauses(errs ...error) ErrorWrapper
+	WithField(key string, value interface{}) ErrorWrapper
+	WithTemporary(temporary bool) ErrorWrapper
+	WithTimeout(timeout bool) ErrorWrapper
+	WithUserFacing(userFacing bool) ErrorWrapper
+	WithBreakerTrip(trip bool) ErrorWrapper
+	WithFingerprint(fingerprint string) ErrorWrapper
+	WithDeadline(deadline time.Time) ErrorWrapper
+	WithHint(hint string) ErrorWrapper
+	WithResource(resource string) ErrorWrapper
+	WithLevel(level Level) ErrorWrapper
+	WithSeverity(severity Severity) ErrorWrapper
+	WithQuery(query string, args ...interface{}) ErrorWrapper
+	WithHeader(key, value string) ErrorWrapper
+	WithLocale(lang string) ErrorWrapper
+	WithTraceID(id string) ErrorWrapper
+	WithOperation(op string) ErrorWrapper
 	FromDefinition(cause error) ErrorWrapper
+	Clone() ErrorWrapper
+	Detach() ErrorWrapper
+	WithDefinitionStatus(status int) ErrorWrapper
 	Is(target error) bool
+	Equal(other ErrorWrapper) bool
 }
 
 type wrapper struct {
 	code    []string
 	message []string
+	public  []string
 	status  []statusCode
-	cause   error
+	causes  []error
+	fields  map[string]interface{}
+	headers http.Header
+	locale  string
+	traceID string
+	ops     []string
+
+	fingerprint string
+	hint        string
+	resource    string
+
+	temporary   *bool
+	timeout     *bool
+	userFacing  *bool
+	breakerTrip *bool
+	level       *Level
+
+	query     string
+	queryArgs []interface{}
+
+	sequence uint64
+	stack    []uintptr
 	*sync.RWMutex
 }
 
@@ -42,18 +109,64 @@ type statusCode struct {
 	code    int
 }
 
-func (e wrapper) Error() string {
-	parts := make([]string, 0)
-	if e.cause != nil {
-		parts = append(parts, fmt.Sprintf("cause: [%s]", e.cause.Error()))
+func (e *wrapper) Error() string {
+	if e == nil {
+		return ""
+	}
+	defer e.rlock()()
+	return e.render()
+}
+
+func (e wrapper) render() string {
+	if formatted, ok := e.formatTemplate(); ok {
+		return formatted
+	}
+	if len(e.code) == 1 && len(e.causes) == 0 && len(e.message) == 0 && len(e.status) == 0 && !needsQuoting(e.code[0]) {
+		return "code: [" + e.code[0] + "]"
+	}
+	parts := make([]string, 0, 4)
+	if len(e.causes) > 0 {
+		// Wrapper causes are already unambiguous, so only the other causes are quoted, which keeps the
+		// escaping from growing with each level of wrapping.
+		causes := make([]string, len(e.causes))
+		for i, cause := range e.causes {
+			causes[i] = cause.Error()
+			if _, ok := asWrapper(cause); !ok {
+				causes[i] = quoteValue(causes[i])
+			}
+		}
+		parts = append(parts, fmt.Sprintf("cause: [%s]", strings.Join(causes, ", ")))
 	}
 	if len(e.code) > 0 {
-		codeStr := e.codeString()
-		codeErr := strings.ReplaceAll(codeStr, "\"", "")
-		parts = append(parts, fmt.Sprintf("code: %s", codeErr))
+		parts = append(parts, fmt.Sprintf("code: %s", formatList(e.code)))
 	}
-	joinedParts := strings.Join(parts[:], "; ")
-	return errors.New(fmt.Sprintf("%s", joinedParts)).Error()
+	if len(e.message) > 0 {
+		parts = append(parts, fmt.Sprintf("message: %s", formatList(e.message)))
+	}
+	if len(e.status) > 0 {
+		status := make([]string, len(e.status))
+		for i, v := range e.status {
+			status[i] = strconv.Itoa(v.code)
+		}
+		parts = append(parts, fmt.Sprintf("status: %s", formatList(status)))
+	}
+	return strings.Join(parts[:], getSeparator())
+}
+
+// PublicError returns the client-safe messages of the error, or a generic message when there is none.
+func (e *wrapper) PublicError() string {
+	if e == nil {
+		return defaultPublicMessage
+	}
+	defer e.rlock()()
+	return strings.Join(e.publicMessages(), "; ")
+}
+
+func (e wrapper) publicMessages() []string {
+	if len(e.public) == 0 {
+		return []string{defaultPublicMessage}
+	}
+	return append([]string(nil), e.public...)
 }
 
 // String returns an string containing all the internal information about the given error.
@@ -61,6 +174,7 @@ func (e *wrapper) String() string {
 	if e == nil {
 		return ""
 	}
+	defer e.rlock()()
 	parts := make([]string, 0)
 	if len(e.code) > 0 {
 		parts = append(parts, fmt.Sprintf("\"code\": %s", e.codeString()))
@@ -68,49 +182,230 @@ func (e *wrapper) String() string {
 	if len(e.message) > 0 {
 		parts = append(parts, fmt.Sprintf("\"message\": %s", e.messageString()))
 	}
+	if len(e.public) > 0 {
+		parts = append(parts, fmt.Sprintf("\"public_message\": %s", joinToString(e.public)))
+	}
 	if len(e.status) > 0 {
 		parts = append(parts, fmt.Sprintf("\"status\": %s", e.statusString()))
 	}
-	if e.cause != nil {
-		parts = append(parts, fmt.Sprintf("\"cause\": \"%s\"", e.cause.Error()))
+	safe := isSafeStringMode()
+	if len(e.causes) > 0 && !safe {
+		parts = append(parts, fmt.Sprintf("\"cause\": \"%s\"", e.causeString()))
+	}
+	fields := e.fields
+	if safe {
+		fields = safeFields(fields)
+	}
+	if len(fields) > 0 {
+		parts = append(parts, fmt.Sprintf("\"fields\": %s", fieldsString(fields)))
 	}
 	joinedParts := strings.Join(parts[:], ", ")
 	return fmt.Sprintf("{%s}", joinedParts)
 }
 
 func (e *wrapper) Json() map[string]interface{} {
-	jsonMap := make(map[string]interface{})
 	if e == nil {
-		return jsonMap
-	}
-	err := json.Unmarshal([]byte(e.Error()), &jsonMap)
-	if err != nil {
-		log.New(os.Stderr, "ERROR", 0).Println("error parsing wrapperrors map: %s", err.Error())
+		return make(map[string]interface{})
 	}
-	return jsonMap
+	defer e.rlock()()
+	return e.toMap()
 }
 
 func (e *wrapper) WithMessage(message string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithMessage(message) })
+	}
 	e.Lock()
 	defer e.Unlock()
 	e.message = wrapMessage(message, e)
 	return e
 }
 
+// WithPublicMessage adds a client-safe message. Only these messages are sent to clients by
+// WriteResponse and ToProblem9457, while the other messages stay internal.
+func (e *wrapper) WithPublicMessage(message string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithPublicMessage(message) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.public = append(e.public, message)
+	return e
+}
+
+func (e *wrapper) WithMessagef(format string, args ...interface{}) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithMessagef(format, args...) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.message = wrapMessage(fmt.Sprintf(format, args...), e)
+	return e
+}
+
+func (e *wrapper) WithCode(code string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithCode(code) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.code = append(e.code, code)
+	return e
+}
+
 func (e *wrapper) WithStatus(status int) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithStatus(status) })
+	}
 	e.Lock()
 	defer e.Unlock()
 	e.status = wrapStatus(status, e)
+	if e.stack == nil && captureStackForStatus(status) {
+		e.stack = callers()
+	}
 	return e
 }
 
+func (e *wrapper) WithStatusText(status int, text string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithStatusText(status, text) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.status = append(e.status, statusCode{message: text, code: status})
+	if e.stack == nil && captureStackForStatus(status) {
+		e.stack = callers()
+	}
+	return e
+}
+
+// WithCause adds the given error as a cause. Fields carried along its cause chain are inherited unless
+// the error already holds the same key. A deadline or cancellation cause adds a 504 or 499 status, as
+// with New.
 func (e *wrapper) WithCause(err error) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithCause(err) })
+	}
+	inherited := AllFields(err)
+	status, reason, deadline := deadlineCause(err)
 	e.Lock()
 	defer e.Unlock()
-	e.cause = wrapCause(err, e)
+	e.causes = wrapCause(err, e)
+	e.inheritFields(inherited)
+	if deadline {
+		e.applyDeadline(status, reason)
+	}
 	return e
 }
 
+// WithCauses adds all the given errors as causes at once, skipping nil errors. Fields are inherited and
+// deadlines detected as with WithCause, earlier causes winning over later ones.
+func (e *wrapper) WithCauses(errs ...error) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithCauses(errs...) })
+	}
+	inherited := make([]map[string]interface{}, len(errs))
+	var status statusCode
+	var reason string
+	deadline := false
+	for i, err := range errs {
+		inherited[i] = AllFields(err)
+		if !deadline {
+			status, reason, deadline = deadlineCause(err)
+		}
+	}
+	e.Lock()
+	defer e.Unlock()
+	for i, err := range errs {
+		e.causes = wrapCause(err, e)
+		e.inheritFields(inherited[i])
+	}
+	if deadline {
+		e.applyDeadline(status, reason)
+	}
+	return e
+}
+
+func (e *wrapper) WithField(key string, value interface{}) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithField(key, value) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.fields = wrapField(key, value, e)
+	return e
+}
+
+func (e *wrapper) WithUserFacing(userFacing bool) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithUserFacing(userFacing) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.userFacing = &userFacing
+	return e
+}
+
+func (e wrapper) toMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	if len(e.code) > 0 {
+		m["code"] = e.code
+	}
+	if message := e.responseMessage(); len(message) > 0 {
+		m["message"] = message
+	}
+	if len(e.status) > 0 {
+		status := make([]map[string]interface{}, len(e.status))
+		for i, v := range e.status {
+			status[i] = map[string]interface{}{"message": v.message, "code": v.code}
+		}
+		m["status"] = status
+	}
+	if len(e.causes) > 0 {
+		m["cause"] = e.causeString()
+	}
+	if len(e.fields) > 0 {
+		m["fields"] = copyFields(e.fields)
+	}
+	if traceID := e.resolveTraceID(); traceID != "" {
+		m["trace_id"] = traceID
+	}
+	if hint := e.resolveHint(); hint != "" {
+		m["hint"] = hint
+	}
+	if resource := e.resolveResource(); resource != "" {
+		m["resource"] = resource
+	}
+	return m
+}
+
+// Unwrap returns the causes of the error, allowing errors.Is and errors.As to traverse them.
+func (e *wrapper) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	defer e.rlock()()
+	return e.causes[:len(e.causes):len(e.causes)]
+}
+
+func (e wrapper) cause() error {
+	switch len(e.causes) {
+	case 0:
+		return nil
+	case 1:
+		return e.causes[0]
+	}
+	return errors.Join(e.causes...)
+}
+
+func (e wrapper) causeString() string {
+	parts := make([]string, len(e.causes))
+	for i, cause := range e.causes {
+		parts[i] = cause.Error()
+	}
+	return strings.Join(parts[:], getSeparator())
+}
+
 func (e wrapper) codeString() string {
 	return joinToString(e.code)
 }
@@ -119,6 +414,14 @@ func (e wrapper) messageString() string {
 	return joinToString(e.message)
 }
 
+func fieldsString(fields map[string]interface{}) string {
+	marshaled, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Sprintf("\"%v\"", fields)
+	}
+	return string(marshaled)
+}
+
 func (e wrapper) statusString() string {
 	s := make([]interface{}, len(e.status))
 	for i, v := range e.status {
@@ -132,69 +435,230 @@ func (e wrapper) statusString() string {
 
 // Is verify if a given error has the same time of the given target error.
 // The target parameter should be an error previously defined with the Define function.
-func (e wrapper) Is(target error) bool {
-	if targetErr, ok := target.(wrapper); ok {
-		return strings.Join(e.code[:], "; ") == strings.Join(targetErr.code[:], ";")
+// The error matches when its codes start with the target codes, so codes appended with WithCode still
+// match the base definition. Errors without code never match, and targets not created by this package
+// are left to errors.Is.
+func (e *wrapper) Is(target error) bool {
+	targetErr, ok := asWrapper(target)
+	if e == nil || !ok {
+		return false
+	}
+	// Definitions are never modified, so their codes are read without copying them.
+	targetCodes := targetErr.code
+	if targetErr.RWMutex != nil {
+		targetCodes = targetErr.codes()
 	}
-	return e.Error() == target.Error()
+	if e.RWMutex != nil {
+		e.RLock()
+		defer e.RUnlock()
+	}
+	if len(targetCodes) == 0 || len(targetCodes) > len(e.code) {
+		return false
+	}
+	return equalStrings(e.code[:len(targetCodes)], targetCodes)
 }
 
 // Is verify if a given error has the same time of the given target error.
-// The target parameter should be an error previously defined with the Define function.
+// The target parameter should be an error previously defined with the Define function. Errors created by
+// this package match when their codes start with the target codes, as with errors.Is, compared code by
+// code; other errors only match themselves.
 func Is(e error, target error) bool {
-	err, eOk := e.(wrapper)
-	targetErr, tOk := target.(wrapper)
-	if eOk && tOk {
-		return err.String() == targetErr.String()
+	if e == target {
+		return true
+	}
+	wp, ok := asWrapper(e)
+	if !ok {
+		return false
 	}
-	return e == target
+	if _, ok := asWrapper(target); !ok {
+		return false
+	}
+	return wp.Is(target)
 }
 
-// Define define a new error base model.
+// SameKind reports whether both errors are wrappers of the same kind: their codes are the same
+// regardless of order and their status codes are identical. Unlike Is, which checks an error against a
+// definition, SameKind is symmetric and ignores messages and causes, which makes it suitable for
+// comparing errors in tests.
+func SameKind(a, b error) bool {
+	aErr, aOk := asWrapper(a)
+	bErr, bOk := asWrapper(b)
+	if !aOk || !bOk {
+		return false
+	}
+	aCodes, aStatus := aErr.kind()
+	bCodes, bStatus := bErr.kind()
+	if len(aCodes) != len(bCodes) || len(aStatus) != len(bStatus) {
+		return false
+	}
+	counts := make(map[string]int, len(aCodes))
+	for _, code := range aCodes {
+		counts[code]++
+	}
+	for _, code := range bCodes {
+		if counts[code] == 0 {
+			return false
+		}
+		counts[code]--
+	}
+	for i := range aStatus {
+		if aStatus[i] != bStatus[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Define define a new error base model. Errors defined with a zero status use the default status set
+// with SetDefaultStatus. The enricher set with SetGlobalEnricher is applied to the definition. Definitions
+// are never modified: the builders called on them return a modified clone instead, created as by
+// FromDefinition.
 func Define(code string, status int) ErrorWrapper {
-	return &wrapper{
-		code: []string{code},
-		status: []statusCode{
+	wp := &wrapper{
+		code:     []string{code},
+		causes:   nil,
+		sequence: nextSequence(),
+	}
+	if status != 0 {
+		wp.status = []statusCode{
 			{
 				message: getStatusText(status),
 				code:    status,
 			},
-		},
-		cause: nil,
+		}
+	}
+	// The enricher may use the builders, so the definition only loses its mutex afterwards.
+	wp.RWMutex = &sync.RWMutex{}
+	e := enrich(wp)
+	wp.RWMutex = nil
+	if enriched, ok := asWrapper(e); ok {
+		enriched.RWMutex = nil
 	}
+	return e
+}
+
+// MustDefine is like Define but panics when the code is empty.
+func MustDefine(code string, status int) ErrorWrapper {
+	return Must(Define(code, status))
+}
+
+// DefineInferred is like Define but takes the status from a leading HTTP status in the code, e.g.
+// "404_not_found" defines not_found with status 404. Codes without such a prefix get status 500.
+func DefineInferred(code string) ErrorWrapper {
+	if prefix, rest, found := strings.Cut(code, "_"); found && len(prefix) == 3 {
+		if status, err := strconv.Atoi(prefix); err == nil && status >= 100 && status <= 599 {
+			return Define(rest, status)
+		}
+	}
+	return Define(code, http.StatusInternalServerError)
+}
+
+// Must panics when the given error is nil or has an empty code, surfacing malformed definitions at
+// startup. It returns the error otherwise.
+func Must(e ErrorWrapper) ErrorWrapper {
+	if e == nil || strings.TrimSpace(Code(e)) == "" {
+		panic("wrapperrors: error definition with an empty code")
+	}
+	return e
 }
 
 // New creates a new error from a given message and raw error.
 func New(code string, cause error) ErrorWrapper {
-	return newError(code, cause)
+	warnDeprecated("New", "Define and FromDefinition")
+	wp := newError(code, cause)
+	if definition, ok := lookupMapping(cause); ok {
+		for _, status := range definition.status {
+			wp.WithStatus(status.code)
+		}
+	}
+	return created(wp)
+}
+
+// NewDetailed creates a new error from a given code with both a client-safe public message and an
+// internal diagnostic message.
+func NewDetailed(code string, publicMsg, internalMsg string, cause error) ErrorWrapper {
+	wp := newError(code, cause)
+	wp.public = append(wp.public, publicMsg)
+	wp.message = append(wp.message, internalMsg)
+	return created(wp)
 }
 
-// FromDefinition creates a new error from a given pre-definition.
-func (e wrapper) FromDefinition(cause error) ErrorWrapper {
-	wp := newError(Code(e), cause)
-	for _, status := range e.status {
-		wp.WithStatus(status.code)
+// Newf creates a new error from a given code and a formatted message.
+func Newf(code string, format string, args ...interface{}) ErrorWrapper {
+	wp := newError(code, nil)
+	wp.WithMessagef(format, args...)
+	return created(wp)
+}
+
+// Errorf creates a new error from a given code and a message formatted like fmt.Errorf. The arguments
+// of the %w verbs are kept as causes, in order, and are formatted in the message like with %v.
+func Errorf(code string, format string, args ...interface{}) ErrorWrapper {
+	formatted := fmt.Errorf(format, args...)
+	wp := newError(code, nil)
+	for _, cause := range unwrapAll(formatted) {
+		wp.causes = wrapCause(cause, wp)
+		wp.detectDeadline(cause)
 	}
-	return wp
+	wp.message = wrapMessage(formatted.Error(), wp)
+	return created(wp)
 }
 
-// Wrap wraps an error with a message.
+// FromDefinition creates a new error from a given pre-definition, carrying its code, status, default
+// messages and level, with the given error as cause.
+func (e *wrapper) FromDefinition(cause error) ErrorWrapper {
+	return created(e.fromDefinition(cause))
+}
+
+// Wrap wraps an error with a message. The new error inherits the fields carried along the cause chain.
 func Wrap(e error, message string) ErrorWrapper {
+	warnDeprecated("Wrap", "Wrapf")
 	return wrap(e, message)
 }
 
+// Wrapf wraps an error with a formatted message, keeping the original error as cause and inheriting
+// its fields.
+func Wrapf(e error, format string, args ...interface{}) ErrorWrapper {
+	return wrap(e, fmt.Sprintf(format, args...))
+}
+
+// MapMessages returns a copy of the given error with fn applied to each of its messages.
+func MapMessages(e error, fn func(string) string) ErrorWrapper {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return UnknownError.FromDefinition(e)
+	}
+	cp := wp.clone()
+	for i, message := range cp.message {
+		cp.message[i] = fn(message)
+	}
+	return cp
+}
+
 // Code retrieves the error internal code of a given error.
 func Code(e error) string {
-	if err, ok := e.(wrapper); ok {
+	if err, ok := asWrapper(e); ok {
+		defer err.rlock()()
 		return strings.Join(err.code[:], "; ")
 	}
 
 	return ""
 }
 
+// CodePath retrieves the error internal code of a given error joined with the given separator, e.g.
+// "parent.child" for codes added with WithCode and a "." separator.
+func CodePath(e error, sep string) string {
+	if err, ok := asWrapper(e); ok {
+		defer err.rlock()()
+		return strings.Join(err.code, sep)
+	}
+
+	return ""
+}
+
 // Message retrieves the error internal message of a given error.
 func Message(e error) string {
-	if err, ok := e.(wrapper); ok {
+	if err, ok := asWrapper(e); ok {
+		defer err.rlock()()
 		return strings.Join(err.message[:], "; ")
 	}
 
@@ -203,27 +667,338 @@ func Message(e error) string {
 
 // Status retrieves the error internal status of a given error.
 func Status(e error) string {
-	if wp, ok := e.(wrapper); ok {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
 		return wp.statusString()
 	}
 
 	return ""
 }
 
-func newError(code string, cause error) ErrorWrapper {
-	return &wrapper{
-		code:    []string{code},
-		cause:   cause,
-		RWMutex: &sync.RWMutex{},
+// Cause retrieves the cause of a given error, one level deep. Multiple causes are returned joined with
+// errors.Join. It returns nil when there is no cause or the error was not created by this package.
+func Cause(e error) error {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		return wp.cause()
+	}
+
+	return nil
+}
+
+// Tag renders the code and status of a given error as a compact tag suitable for metric labels,
+// e.g. "not_found:404". Multiple codes are joined with dots and errors without code are "unknown".
+func Tag(e error) string {
+	code := "unknown"
+	if wp, ok := asWrapper(e); ok {
+		if codes := wp.codes(); len(codes) > 0 {
+			code = strings.Join(codes, ".")
+		}
+	}
+
+	return fmt.Sprintf("%s:%d", code, GetStatusCode(e))
+}
+
+// Fields retrieves a copy of the metadata fields of a given error.
+func Fields(e error) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		for key, value := range wp.fields {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
+
+// GetStatusCode retrieves the last status code of a given error, falling back to the default status
+// (500 unless changed with SetDefaultStatus) when there is none.
+func GetStatusCode(e error) int {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		if len(wp.status) > 0 {
+			return wp.status[len(wp.status)-1].code
+		}
+	}
+
+	return DefaultStatus()
+}
+
+// Peek returns the first code and the status code of a given error without allocating, for hot paths
+// such as logging. The status is resolved as with GetStatusCode. ok is false for errors not created by
+// this package.
+func Peek(e error) (code string, status int, ok bool) {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return "", 0, false
+	}
+	if wp.RWMutex != nil {
+		wp.RLock()
+		defer wp.RUnlock()
+	}
+	if len(wp.code) > 0 {
+		code = wp.code[0]
+	}
+	if len(wp.status) > 0 {
+		return code, wp.status[len(wp.status)-1].code, true
+	}
+	return code, DefaultStatus(), true
+}
+
+// SetDefaultStatus sets the status used by errors defined without a status and by non-wrapper errors.
+func SetDefaultStatus(status int) {
+	atomic.StoreInt64(&defaultStatus, int64(status))
+}
+
+// DefaultStatus retrieves the status set with SetDefaultStatus.
+func DefaultStatus() int {
+	return int(atomic.LoadInt64(&defaultStatus))
+}
+
+// IsClientError reports whether a given error has a 4xx status code.
+func IsClientError(e error) bool {
+	return Category(e) == "client"
+}
+
+// IsServerError reports whether a given error has a 5xx status code.
+func IsServerError(e error) bool {
+	return Category(e) == "server"
+}
+
+// Category buckets a given error by its status code into "client" (4xx), "server" (5xx) or "unknown".
+// Errors not created by this package are "unknown".
+func Category(e error) string {
+	if _, ok := asWrapper(e); !ok {
+		return "unknown"
+	}
+	switch GetStatusCode(e) / 100 {
+	case 4:
+		return "client"
+	case 5:
+		return "server"
+	}
+
+	return "unknown"
+}
+
+// IsUserFacing reports whether the message of a given error can be shown to end users. Unless set
+// with WithUserFacing, errors with a 4xx status are user facing.
+func IsUserFacing(e error) bool {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return false
+	}
+	unlock := wp.rlock()
+	userFacing := wp.userFacing
+	unlock()
+	if userFacing != nil {
+		return *userFacing
+	}
+	status := GetStatusCode(wp)
+	return status >= http.StatusBadRequest && status < http.StatusInternalServerError
+}
+
+// Sequence retrieves the process-wide creation sequence number of a given error. Sequence numbers
+// increase monotonically with every created error; clones keep the sequence of their original.
+func Sequence(e error) uint64 {
+	if wp, ok := asWrapper(e); ok {
+		return wp.sequence
+	}
+
+	return 0
+}
+
+func nextSequence() uint64 {
+	return atomic.AddUint64(&sequence, 1)
+}
+
+func newError(code string, cause error) *wrapper {
+	wp := allocWrapper()
+	wp.code = []string{code}
+	wp.sequence = nextSequence()
+	wp.level = defaultLevel(wp.code)
+	wp.causes = wrapCause(cause, wp)
+	wp.detectDeadline(cause)
+	if captureStackOnCreation() {
+		wp.stack = callers()
 	}
+	return wp
 }
 
 func wrap(e error, message string) ErrorWrapper {
-	if err, ok := e.(wrapper); ok {
-		return err.WithMessage(message).WithCause(e)
+	var wp *wrapper
+	if err, ok := asWrapper(e); ok {
+		wp = err.derive(e)
+	} else if definition, ok := lookupMapping(e); ok {
+		wp = definition.fromDefinition(e)
+	} else {
+		wp = unknownError(e)
+	}
+	if len(wp.stack) > 0 {
+		wp.stack = trimStack(wp.stack, e)
+	}
+	wp.inheritFields(AllFields(e))
+	wp.detectDeadline(e)
+	wp.WithMessage(message)
+	return created(wp)
+}
+
+func unknownError(cause error) *wrapper {
+	definition, _ := asWrapper(UnknownError)
+	return definition.fromDefinition(cause)
+}
+
+// fromDefinition creates a new error carrying the code, status, default messages and level of the
+// definition, with the given error as cause.
+func (e *wrapper) fromDefinition(cause error) *wrapper {
+	wp := e.derive(cause)
+	unlock := e.rlock()
+	wp.message = append([]string(nil), e.message...)
+	wp.public = append([]string(nil), e.public...)
+	unlock()
+	return wp
+}
+
+// derive creates a new error of the same kind as e, carrying its code, status and level but none of
+// its messages, with the given error as cause.
+func (e *wrapper) derive(cause error) *wrapper {
+	wp := newError("", cause)
+	unlock := e.rlock()
+	wp.code = append([]string(nil), e.code...)
+	wp.status = append([]statusCode(nil), e.status...)
+	wp.level = e.level
+	unlock()
+	if wp.level == nil {
+		wp.level = defaultLevel(wp.code)
+	}
+	for _, status := range wp.status {
+		if wp.stack == nil && captureStackForStatus(status.code) {
+			wp.stack = callers()
+		}
+	}
+	return wp
+}
+
+// Clone returns a deep copy of the error with its own mutex, so it can be enriched without
+// mutating the original.
+func (e *wrapper) Clone() ErrorWrapper {
+	return e.clone()
+}
+
+// Detach returns a copy of the error without its causes nor debug-only query, keeping code, message,
+// status and fields, so it can be returned to clients while the original is logged.
+func (e *wrapper) Detach() ErrorWrapper {
+	cp := e.clone()
+	cp.causes = nil
+	cp.query = ""
+	cp.queryArgs = nil
+	return cp
+}
+
+// WithDefinitionStatus returns a new definition with the status of the original replaced by the given
+// one, leaving the original definition untouched. As with Define, the new definition is never modified.
+func (e *wrapper) WithDefinitionStatus(status int) ErrorWrapper {
+	cp := e.clone()
+	cp.sequence = nextSequence()
+	cp.status = []statusCode{
+		{
+			message: getStatusText(status),
+			code:    status,
+		},
 	}
+	cp.RWMutex = nil
+	return cp
+}
+
+func (e *wrapper) clone() *wrapper {
+	defer e.rlock()()
+	return &wrapper{
+		code:    append([]string(nil), e.code...),
+		message: append([]string(nil), e.message...),
+		public:  append([]string(nil), e.public...),
+		status:  append([]statusCode(nil), e.status...),
+		causes:  append([]error(nil), e.causes...),
+		fields:  copyFields(e.fields),
+		headers: e.headers.Clone(),
+		locale:  e.locale,
+		traceID: e.traceID,
+		ops:     append([]string(nil), e.ops...),
+
+		fingerprint: e.fingerprint,
+		hint:        e.hint,
+		resource:    e.resource,
+
+		temporary:   e.temporary,
+		timeout:     e.timeout,
+		userFacing:  e.userFacing,
+		breakerTrip: e.breakerTrip,
+		level:       e.level,
+
+		query:     e.query,
+		queryArgs: append([]interface{}(nil), e.queryArgs...),
 
-	return UnknownError.WithCause(e).WithMessage(message)
+		sequence: e.sequence,
+		stack:    e.stack,
+		RWMutex:  &sync.RWMutex{},
+	}
+}
+
+// mutable returns the error itself, or a clone of it when it is a definition created by Define, so that
+// the builders never modify definitions shared across the application.
+func (e *wrapper) mutable() *wrapper {
+	if e.RWMutex == nil {
+		return e.clone()
+	}
+	return e
+}
+
+// rlock read-locks the error and returns the matching unlock function. Definitions created by Define
+// have no mutex, since they are never mutated.
+func (e *wrapper) rlock() func() {
+	if e.RWMutex == nil {
+		return func() {}
+	}
+	e.RLock()
+	return e.RUnlock
+}
+
+// codes returns a copy of the codes of the error.
+func (e *wrapper) codes() []string {
+	defer e.rlock()()
+	return append([]string(nil), e.code...)
+}
+
+// kind returns a copy of the codes and status codes of the error.
+func (e *wrapper) kind() ([]string, []int) {
+	defer e.rlock()()
+	status := make([]int, len(e.status))
+	for i, v := range e.status {
+		status[i] = v.code
+	}
+	return append([]string(nil), e.code...), status
+}
+
+func asWrapper(e error) (*wrapper, bool) {
+	switch err := e.(type) {
+	case *wrapper:
+		return err, err != nil
+	case *ValidationError:
+		if err != nil {
+			return err.wrapper, err.wrapper != nil
+		}
+	}
+	return nil, false
+}
+
+func rootCause(e error) error {
+	for {
+		wp, ok := asWrapper(e)
+		if !ok || len(wp.causes) == 0 {
+			return e
+		}
+		e = wp.causes[0]
+	}
 }
 
 func wrapMessage(message string, e *wrapper) []string {
@@ -241,14 +1016,58 @@ func wrapStatus(status int, e *wrapper) []statusCode {
 	return append(e.status, newStatus)
 }
 
-func wrapCause(err error, e *wrapper) error {
-	if e.cause == nil {
-		return err
+func wrapCause(err error, e *wrapper) []error {
+	if err == nil {
+		return e.causes
+	}
+	return append(e.causes, err)
+}
+
+// inheritFields adds the given fields to the error, keeping its own values on key conflicts.
+func (e *wrapper) inheritFields(fields map[string]interface{}) {
+	for key, value := range fields {
+		if _, exists := e.fields[key]; !exists {
+			e.fields = wrapField(key, value, e)
+		}
 	}
-	return errors.New(fmt.Sprintf("%v; %v;", e.cause.Error(), err.Error()))
+}
+
+func wrapField(key string, value interface{}, e *wrapper) map[string]interface{} {
+	if e.fields == nil {
+		return map[string]interface{}{key: value}
+	}
+	e.fields[key] = value
+	return e.fields
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	cp := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		cp[key] = value
+	}
+	return cp
+}
+
+// AddStatusTextResolver registers a resolver for status texts. Resolvers are tried in registration
+// order before falling back to the HTTP status text and then to the numeric status.
+func AddStatusTextResolver(resolver func(int) (string, bool)) {
+	statusTextResolversMu.Lock()
+	defer statusTextResolversMu.Unlock()
+	statusTextResolvers = append(statusTextResolvers, resolver)
 }
 
 func getStatusText(status int) string {
+	statusTextResolversMu.RLock()
+	resolvers := statusTextResolvers
+	statusTextResolversMu.RUnlock()
+	for _, resolver := range resolvers {
+		if statusText, ok := resolver(status); ok {
+			return statusText
+		}
+	}
 	statusText := http.StatusText(status)
 	if statusText == "" {
 		return strconv.Itoa(status)
@@ -266,6 +1085,28 @@ func joinToString(arr []string) string {
 	})
 }
 
+// formatList renders the given values between brackets, quoting the values that contain separators,
+// brackets, quotes or control characters so that the output stays unambiguous.
+func formatList(values []string) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = quoteValue(value)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(formatted[:], ", "))
+}
+
+// quoteValue quotes the given value when it needs quoting, returning it unchanged otherwise.
+func quoteValue(value string) string {
+	if needsQuoting(value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func needsQuoting(value string) bool {
+	return value == "" || strings.ContainsAny(value, "\"\\,;[]\n\r\t")
+}
+
 func mapToString(arr []interface{}, mapFn func(item interface{}) string) string {
 	buff := strings.Builder{}
 	buff.WriteString("[")
@@ -0,0 +1,75 @@
+package wrapperrors
+
+import (
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const defaultSeparator = "; "
+
+var (
+	formatTemplate   *template.Template
+	formatTemplateMu sync.RWMutex
+
+	separator   = defaultSeparator
+	separatorMu sync.RWMutex
+)
+
+type formatData struct {
+	Code    []string
+	Message []string
+	Status  []int
+	Cause   string
+	Fields  map[string]interface{}
+}
+
+// SetFormatTemplate sets a template used to render the Error output of every error. The template can
+// access .Code, .Message, .Status, .Cause and .Fields. Passing nil restores the default formatting.
+func SetFormatTemplate(tmpl *template.Template) {
+	formatTemplateMu.Lock()
+	defer formatTemplateMu.Unlock()
+	formatTemplate = tmpl
+}
+
+// SetSeparator sets the delimiter placed between the parts of Error and between multiple causes in
+// String, Json and EncodeJSON, "; " by default. An empty separator restores the default.
+func SetSeparator(sep string) {
+	separatorMu.Lock()
+	defer separatorMu.Unlock()
+	if sep == "" {
+		sep = defaultSeparator
+	}
+	separator = sep
+}
+
+func getSeparator() string {
+	separatorMu.RLock()
+	defer separatorMu.RUnlock()
+	return separator
+}
+
+func (e wrapper) formatTemplate() (string, bool) {
+	formatTemplateMu.RLock()
+	tmpl := formatTemplate
+	formatTemplateMu.RUnlock()
+	if tmpl == nil {
+		return "", false
+	}
+	status := make([]int, len(e.status))
+	for i, v := range e.status {
+		status[i] = v.code
+	}
+	data := formatData{
+		Code:    e.code,
+		Message: e.message,
+		Status:  status,
+		Cause:   e.causeString(),
+		Fields:  e.fields,
+	}
+	buff := strings.Builder{}
+	if err := tmpl.Execute(&buff, data); err != nil {
+		return "", false
+	}
+	return buff.String(), true
+}
@@ -0,0 +1,101 @@
+package wrapperrors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StableID returns a deterministic identifier computed from the error code, status and root cause
+// mixed with the given salt. Identical errors hashed with the same salt always yield the same ID.
+func StableID(err error, salt string) string {
+	hash := sha256.New()
+	hash.Write([]byte(salt))
+	hash.Write([]byte{0})
+	if wp, ok := asWrapper(err); ok {
+		unlock := wp.rlock()
+		for _, code := range wp.code {
+			hash.Write([]byte(code))
+			hash.Write([]byte{0})
+		}
+		for _, status := range wp.status {
+			hash.Write([]byte(strconv.Itoa(status.code)))
+			hash.Write([]byte{0})
+		}
+		unlock()
+	}
+	if root := rootCause(err); root != nil {
+		if _, ok := asWrapper(root); !ok {
+			hash.Write([]byte(root.Error()))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Slug returns a short filesystem-safe identifier for the given error made of its code and a short
+// hash, e.g. "not_found-a1b2c3". The same error always produces the same slug.
+func Slug(err error) string {
+	name := "error"
+	if wp, ok := asWrapper(err); ok {
+		if codes := wp.codes(); len(codes) > 0 {
+			name = strings.Join(codes, "_")
+		}
+	}
+	safeName := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+	return safeName + "-" + StableID(err, "")[:6]
+}
+
+func (e *wrapper) WithFingerprint(fingerprint string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithFingerprint(fingerprint) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.fingerprint = fingerprint
+	return e
+}
+
+// Fingerprint returns an identifier grouping occurrences of the same logical error, e.g. for error
+// aggregation tools. It is computed from the codes of the cause chain and the type of the root cause
+// only, so messages, statuses and field values do not affect it. A fingerprint set with WithFingerprint
+// on any error of the chain takes precedence.
+func Fingerprint(e error) string {
+	var codes []string
+	override := ""
+	visitChain(e, func(err error) bool {
+		wp, ok := asWrapper(err)
+		if !ok {
+			return true
+		}
+		unlock := wp.rlock()
+		fingerprint := wp.fingerprint
+		codes = append(codes, wp.code...)
+		unlock()
+		if fingerprint != "" {
+			override = fingerprint
+			return false
+		}
+		return true
+	})
+	if override != "" {
+		return override
+	}
+	hash := sha256.New()
+	for _, code := range codes {
+		hash.Write([]byte(code))
+		hash.Write([]byte{0})
+	}
+	if root := rootCause(e); root != nil {
+		if _, ok := asWrapper(root); !ok {
+			hash.Write([]byte(fmt.Sprintf("%T", root)))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,31 @@
+package wrapperrors
+
+// WithHint sets an actionable suggestion telling how to fix the error, e.g. "check the API key", as
+// opposed to the message telling what went wrong.
+func (e *wrapper) WithHint(hint string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithHint(hint) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.hint = hint
+	return e
+}
+
+// Hint retrieves the suggestion of a given error. Unless set with WithHint, it is inherited from the
+// first error in the cause chain carrying one.
+func Hint(e error) string {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		return wp.resolveHint()
+	}
+
+	return ""
+}
+
+func (e wrapper) resolveHint() string {
+	if e.hint != "" {
+		return e.hint
+	}
+	return e.inheritString(func(wp *wrapper) string { return wp.hint })
+}
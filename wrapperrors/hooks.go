@@ -0,0 +1,69 @@
+package wrapperrors
+
+import "sync"
+
+var (
+	onCreate   func(e ErrorWrapper)
+	onCreateMu sync.RWMutex
+
+	enricher   func(e ErrorWrapper) ErrorWrapper
+	enricherMu sync.RWMutex
+)
+
+// SetOnCreate sets a hook called once for every error produced by New, Newf, NewDetailed,
+// FromDefinition, FromContext, FromRequest, FromSQL or Wrap, e.g. to count errors by code and status.
+// Passing nil removes it.
+func SetOnCreate(hook func(e ErrorWrapper)) {
+	onCreateMu.Lock()
+	defer onCreateMu.Unlock()
+	onCreate = hook
+}
+
+// SetGlobalEnricher sets a function applied once to every error produced by Define and by the
+// constructors calling the SetOnCreate hook, before the hook runs, e.g. to add the service name or
+// version as fields. The error it returns replaces the constructed one, unless it is nil. Passing nil
+// removes it.
+func SetGlobalEnricher(fn func(e ErrorWrapper) ErrorWrapper) {
+	enricherMu.Lock()
+	defer enricherMu.Unlock()
+	enricher = fn
+}
+
+func enrich(wp *wrapper) ErrorWrapper {
+	enricherMu.RLock()
+	fn := enricher
+	enricherMu.RUnlock()
+	if fn == nil {
+		return wp
+	}
+	if enriched := fn(wp); enriched != nil {
+		return enriched
+	}
+	return wp
+}
+
+// created runs the creation hooks for a fully built error. It must not be called while holding the
+// error's lock, since the hooks are user code.
+func created(wp *wrapper) ErrorWrapper {
+	e := enrich(wp)
+	countStatus(GetStatusCode(e))
+	if enriched, ok := asWrapper(e); ok {
+		countThreshold(enriched.codes())
+	}
+	onCreateMu.RLock()
+	hook := onCreate
+	onCreateMu.RUnlock()
+	if hook != nil {
+		hook(e)
+	}
+	return e
+}
+
+// instantiate creates an error from a definition by applying build to a clone with its own sequence
+// number, then runs the creation hooks as FromDefinition does.
+func (e *wrapper) instantiate(build func(*wrapper)) ErrorWrapper {
+	wp := e.clone()
+	wp.sequence = nextSequence()
+	build(wp)
+	return created(wp)
+}
@@ -0,0 +1,242 @@
+package wrapperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	captureHeaders   = []string{"X-Request-ID"}
+	captureHeadersMu sync.RWMutex
+
+	// sensitiveHeaders are never captured by FromRequest, even when configured with SetCaptureHeaders.
+	sensitiveHeaders = map[string]bool{
+		"Authorization":       true,
+		"Proxy-Authorization": true,
+		"Cookie":              true,
+		"Set-Cookie":          true,
+	}
+
+	// exposeInternals is non-zero when the causes and fields of errors are included in client bodies.
+	exposeInternals int32
+)
+
+// SetExposeInternals sets whether the bodies sent to clients by WriteResponse, ResponseBody and
+// EncodeJSON include the causes and fields of the error. They are left out by default, since they may
+// hold implementation details such as SQL text or file paths. The field errors of a ValidationError are
+// always included.
+func SetExposeInternals(expose bool) {
+	var value int32
+	if expose {
+		value = 1
+	}
+	atomic.StoreInt32(&exposeInternals, value)
+}
+
+func exposingInternals() bool {
+	return atomic.LoadInt32(&exposeInternals) != 0
+}
+
+func (e *wrapper) WithHeader(key, value string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithHeader(key, value) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	if e.headers == nil {
+		e.headers = make(http.Header)
+	}
+	e.headers.Add(key, value)
+	return e
+}
+
+// Headers retrieves a copy of the response headers of a given error.
+func Headers(e error) http.Header {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		if wp.headers != nil {
+			return wp.headers.Clone()
+		}
+	}
+
+	return make(http.Header)
+}
+
+// WriteResponse writes the given error as a JSON response using its status code and headers. The body is
+// the one returned by ResponseBody.
+func WriteResponse(w http.ResponseWriter, e error) {
+	wp, ok := asWrapper(e)
+	if !ok {
+		wp = unknownError(e)
+	}
+	for key, values := range Headers(wp) {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(GetStatusCode(wp))
+	if err := json.NewEncoder(w).Encode(responseBody(e, wp)); err != nil {
+		log.New(os.Stderr, "ERROR", 0).Printf("error writing wrapperrors response: %s\n", err.Error())
+	}
+}
+
+// ResponseBody returns the body WriteResponse writes for the given error: its map representation where
+// the messages are replaced by the public messages set with WithPublicMessage, or a generic message when
+// there is none. The causes and fields are left out unless enabled with SetExposeInternals. Errors not
+// created by this package are rendered as an UnknownError.
+func ResponseBody(e error) map[string]interface{} {
+	wp, ok := asWrapper(e)
+	if !ok {
+		wp = unknownError(e)
+	}
+	return responseBody(e, wp)
+}
+
+// responseBody returns the client body of the given error, where wp is its wrapper.
+func responseBody(e error, wp *wrapper) map[string]interface{} {
+	body := wp.Json()
+	if jsonErr, ok := e.(interface{ Json() map[string]interface{} }); ok {
+		body = jsonErr.Json()
+	}
+	unlock := wp.rlock()
+	body["message"] = wp.publicMessages()
+	unlock()
+	if !exposingInternals() {
+		delete(body, "cause")
+		if _, ok := e.(*ValidationError); !ok {
+			delete(body, "fields")
+		}
+	}
+	return body
+}
+
+// SetCaptureHeaders sets the request headers FromRequest stores as fields, X-Request-ID by default.
+// Credentials headers such as Authorization and Cookie are never captured.
+func SetCaptureHeaders(names []string) {
+	captureHeadersMu.Lock()
+	defer captureHeadersMu.Unlock()
+	captureHeaders = append([]string(nil), names...)
+}
+
+// FromRequest creates a new error with the given code whose fields hold the method and path of the
+// request, along with the headers configured with SetCaptureHeaders that are present in it.
+func FromRequest(r *http.Request, code string) ErrorWrapper {
+	wp := newError(code, nil)
+	wp.WithField("method", r.Method)
+	wp.WithField("path", r.URL.Path)
+	captureHeadersMu.RLock()
+	names := captureHeaders
+	captureHeadersMu.RUnlock()
+	for _, name := range names {
+		if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			continue
+		}
+		if value := r.Header.Get(name); value != "" {
+			wp.WithField(name, value)
+		}
+	}
+	return created(wp)
+}
+
+// maxResponseBodySize is the number of bytes of a response body read by FromHTTPResponse.
+const maxResponseBodySize = 1 << 20
+
+// FromHTTPResponse reconstructs an error from a response written by WriteResponse. The messages of the
+// body are kept as both public and internal messages, so the error can be forwarded with WriteResponse.
+// The response status is used when the body holds no status. Bodies that are not an error JSON object,
+// including null and empty objects, become an UnknownError holding the raw body as message and the
+// response status. Only the first MiB of the body is read. The returned error is only set when the body
+// cannot be read; closing the body is left to the caller.
+func FromHTTPResponse(resp *http.Response) (ErrorWrapper, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, err
+	}
+	var response ErrorResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil || len(response.Code) == 0 && len(response.Message) == 0 && len(response.Status) == 0 && response.Cause == "" {
+		wp := unknownError(nil)
+		wp.status = nil
+		wp.WithMessage(string(body))
+		wp.WithStatus(resp.StatusCode)
+		return created(wp), nil
+	}
+	wp := newError("", nil)
+	wp.code = response.Code
+	wp.message = response.Message
+	wp.public = append([]string(nil), response.Message...)
+	for _, status := range response.Status {
+		wp.status = append(wp.status, statusCode{message: status.Message, code: status.Code})
+	}
+	if len(wp.status) == 0 {
+		wp.WithStatus(resp.StatusCode)
+	}
+	if response.Cause != "" {
+		wp.causes = []error{errors.New(response.Cause)}
+	}
+	wp.fields = response.Fields
+	wp.traceID = response.TraceID
+	wp.hint = response.Hint
+	wp.resource = response.Resource
+	return created(wp), nil
+}
+
+// Recoverer is a middleware that recovers from panics, converts the recovered value into an
+// InternalError and logs it along with its stack trace. The response is written from the detached error,
+// so the panic value never reaches clients.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			err := Recover(recovered)
+			log.Printf("wrapperrors: recovered from panic: %+v", err)
+			WriteResponse(w, err.Detach())
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Recover converts a value returned by recover into an InternalError with a captured stack trace,
+// keeping the value as cause when it is an error. It returns nil when nothing was recovered.
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			err = wrapperrors.Recover(r)
+//		}
+//	}()
+func Recover(recovered interface{}) ErrorWrapper {
+	if recovered == nil {
+		return nil
+	}
+	definition, _ := asWrapper(InternalError)
+	wp := definition.fromDefinition(recoveredError(recovered))
+	if len(wp.stack) == 0 {
+		wp.stack = callers()
+	}
+	return created(wp)
+}
+
+func recoveredError(recovered interface{}) error {
+	switch value := recovered.(type) {
+	case error:
+		return value
+	case string:
+		return errors.New(value)
+	default:
+		return fmt.Errorf("%v", value)
+	}
+}
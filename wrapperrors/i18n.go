@@ -0,0 +1,77 @@
+package wrapperrors
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	catalogs        = make(map[string]map[string]string)
+	defaultLanguage = "en"
+	catalogsMu      sync.RWMutex
+)
+
+// RegisterMessages registers a catalog of messages keyed by error code for the given language.
+// Registering the same language again merges the catalogs, the latest message winning.
+func RegisterMessages(lang string, catalog map[string]string) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	if catalogs[lang] == nil {
+		catalogs[lang] = make(map[string]string, len(catalog))
+	}
+	for code, message := range catalog {
+		catalogs[lang][code] = message
+	}
+}
+
+// SetDefaultLanguage sets the language used when a message is missing both from the requested
+// language catalog and from the error itself. It defaults to "en".
+func SetDefaultLanguage(lang string) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	defaultLanguage = lang
+}
+
+func (e *wrapper) WithLocale(lang string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithLocale(lang) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.locale = lang
+	return e
+}
+
+// LocalizedMessage retrieves the message of a given error in the given language. The error codes are
+// looked up in the language catalog, most specific first, falling back to the error's own message and
+// then to the default language catalog.
+func LocalizedMessage(e error, lang string) string {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return ""
+	}
+	defer wp.rlock()()
+	return localizedMessage(wp, lang)
+}
+
+func localizedMessage(e *wrapper, lang string) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	if message, ok := lookupCatalog(catalogs[lang], e.code); ok {
+		return message
+	}
+	if len(e.message) > 0 {
+		return strings.Join(e.message[:], "; ")
+	}
+	message, _ := lookupCatalog(catalogs[defaultLanguage], e.code)
+	return message
+}
+
+func lookupCatalog(catalog map[string]string, codes []string) (string, bool) {
+	for i := len(codes) - 1; i >= 0; i-- {
+		if message, ok := catalog[codes[i]]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
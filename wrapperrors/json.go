@@ -0,0 +1,73 @@
+package wrapperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// jsonError is the lossless JSON representation of an error. Unlike Json and Response, it keeps the
+// internal messages as they are instead of localizing them.
+type jsonError struct {
+	Code    []string               `json:"code"`
+	Message []string               `json:"message"`
+	Status  []StatusEntry          `json:"status"`
+	Cause   *string                `json:"cause,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	TraceID string                 `json:"trace_id,omitempty"`
+}
+
+// MarshalJSON encodes the code, message, status, cause text, fields and trace ID of the error so that
+// UnmarshalJSON can reconstruct it.
+func (e *wrapper) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.jsonError())
+}
+
+func (e *wrapper) jsonError() jsonError {
+	defer e.rlock()()
+	encoded := jsonError{
+		Code:    append([]string{}, e.code...),
+		Message: append([]string{}, e.message...),
+		Status:  make([]StatusEntry, 0, len(e.status)),
+		Fields:  e.fields,
+		TraceID: e.traceID,
+	}
+	for _, status := range e.status {
+		encoded.Status = append(encoded.Status, StatusEntry{Message: status.message, Code: status.code})
+	}
+	if len(e.causes) > 0 {
+		cause := e.causeString()
+		encoded.Cause = &cause
+	}
+	return encoded
+}
+
+// UnmarshalJSON reconstructs an error encoded by MarshalJSON. Causes are restored as a single error
+// holding the cause text.
+func (e *wrapper) UnmarshalJSON(data []byte) error {
+	var decoded jsonError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if e.RWMutex == nil {
+		e.RWMutex = &sync.RWMutex{}
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.code = decoded.Code
+	e.message = decoded.Message
+	e.status = nil
+	for _, status := range decoded.Status {
+		e.status = append(e.status, statusCode{message: status.Message, code: status.Code})
+	}
+	e.causes = nil
+	if decoded.Cause != nil {
+		e.causes = []error{errors.New(*decoded.Cause)}
+	}
+	e.fields = decoded.Fields
+	e.traceID = decoded.TraceID
+	return nil
+}
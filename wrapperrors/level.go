@@ -0,0 +1,122 @@
+package wrapperrors
+
+import (
+	"strconv"
+	"sync"
+)
+
+var (
+	defaultLevels   = make(map[string]Level)
+	defaultLevelsMu sync.RWMutex
+)
+
+// Level represents the severity of an error. Levels are totally ordered from LevelDebug to LevelCritical.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarning
+	LevelError
+	LevelCritical
+)
+
+// Severity is an alias of Level, for code that speaks of error severities rather than levels.
+type Severity = Level
+
+const (
+	SeverityDebug    = LevelDebug
+	SeverityInfo     = LevelInfo
+	SeverityWarning  = LevelWarning
+	SeverityError    = LevelError
+	SeverityCritical = LevelCritical
+)
+
+// String returns the lower case name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarning:
+		return "warning"
+	case LevelError:
+		return "error"
+	case LevelCritical:
+		return "critical"
+	}
+	return "level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// AtLeast reports whether the level is as severe as or more severe than the given level.
+func (l Level) AtLeast(level Level) bool {
+	return l >= level
+}
+
+func (e *wrapper) WithLevel(level Level) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithLevel(level) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.level = &level
+	return e
+}
+
+// WithSeverity is an alias of WithLevel.
+func (e *wrapper) WithSeverity(severity Severity) ErrorWrapper {
+	return e.WithLevel(severity)
+}
+
+// SetDefaultLevel sets the level given to errors created with the given code, e.g. LevelInfo for
+// "not_found", unless overridden with WithLevel. When an error has several codes, the innermost code
+// with a default level wins.
+func SetDefaultLevel(code string, level Level) {
+	defaultLevelsMu.Lock()
+	defer defaultLevelsMu.Unlock()
+	defaultLevels[code] = level
+}
+
+// SetDefaultSeverity is an alias of SetDefaultLevel.
+func SetDefaultSeverity(code string, severity Severity) {
+	SetDefaultLevel(code, severity)
+}
+
+func defaultLevel(codes []string) *Level {
+	defaultLevelsMu.RLock()
+	defer defaultLevelsMu.RUnlock()
+	for i := len(codes) - 1; i >= 0; i-- {
+		if level, ok := defaultLevels[codes[i]]; ok {
+			return &level
+		}
+	}
+	return nil
+}
+
+// GetLevel retrieves the level of a given error. Errors without an explicit level are LevelError.
+func GetLevel(e error) Level {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		if wp.level != nil {
+			return *wp.level
+		}
+	}
+
+	return LevelError
+}
+
+// MaxLevel returns the most severe level among the given errors, ignoring nil errors.
+// It returns LevelDebug when there are no errors.
+func MaxLevel(errs ...error) Level {
+	maxLevel := LevelDebug
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if level := GetLevel(err); level > maxLevel {
+			maxLevel = level
+		}
+	}
+	return maxLevel
+}
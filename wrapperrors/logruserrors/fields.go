@@ -0,0 +1,29 @@
+// Package logruserrors provides logrus integration for wrapperrors.
+package logruserrors
+
+import (
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/sirupsen/logrus"
+)
+
+// LogrusFields extracts the code, status, message and cause of a given error as logrus fields.
+// Errors not created by wrapperrors only produce the cause field.
+func LogrusFields(err error) logrus.Fields {
+	fields := logrus.Fields{}
+	if err == nil {
+		return fields
+	}
+	if code := wrapperrors.Code(err); code != "" {
+		fields["code"] = code
+		fields["status"] = wrapperrors.GetStatusCode(err)
+		if message := wrapperrors.Message(err); message != "" {
+			fields["message"] = message
+		}
+		if cause := wrapperrors.Cause(err); cause != nil {
+			fields["cause"] = cause.Error()
+		}
+		return fields
+	}
+	fields["cause"] = err.Error()
+	return fields
+}
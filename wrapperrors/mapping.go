@@ -0,0 +1,43 @@
+package wrapperrors
+
+import (
+	"errors"
+	"sync"
+)
+
+type mapping struct {
+	sentinel   error
+	definition *wrapper
+}
+
+var (
+	mappings   []mapping
+	mappingsMu sync.RWMutex
+)
+
+// RegisterMapping maps a sentinel error, such as sql.ErrNoRows, to a definition. Wrapping an error
+// that matches the sentinel applies the definition's code and status, while New applies its status.
+// Wrapping an error created by this package keeps its own code and status.
+func RegisterMapping(sentinel error, def ErrorWrapper) {
+	definition, ok := asWrapper(def)
+	if !ok {
+		return
+	}
+	mappingsMu.Lock()
+	defer mappingsMu.Unlock()
+	mappings = append(mappings, mapping{sentinel: sentinel, definition: definition})
+}
+
+func lookupMapping(e error) (*wrapper, bool) {
+	if e == nil {
+		return nil, false
+	}
+	mappingsMu.RLock()
+	defer mappingsMu.RUnlock()
+	for _, m := range mappings {
+		if errors.Is(e, m.sentinel) {
+			return m.definition, true
+		}
+	}
+	return nil, false
+}
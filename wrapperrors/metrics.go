@@ -0,0 +1,46 @@
+package wrapperrors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	statusCounts   = make(map[int]*int64)
+	statusCountsMu sync.RWMutex
+)
+
+// StatusCounts returns how many errors were created for each status code since the last ResetCounts.
+// Errors are counted by every constructor running the SetOnCreate hook, with the status code they have
+// when created.
+func StatusCounts() map[int]int64 {
+	statusCountsMu.RLock()
+	defer statusCountsMu.RUnlock()
+	counts := make(map[int]int64, len(statusCounts))
+	for status, count := range statusCounts {
+		counts[status] = atomic.LoadInt64(count)
+	}
+	return counts
+}
+
+// ResetCounts clears the counts returned by StatusCounts.
+func ResetCounts() {
+	statusCountsMu.Lock()
+	defer statusCountsMu.Unlock()
+	statusCounts = make(map[int]*int64)
+}
+
+func countStatus(status int) {
+	statusCountsMu.RLock()
+	count, ok := statusCounts[status]
+	statusCountsMu.RUnlock()
+	if !ok {
+		statusCountsMu.Lock()
+		if count, ok = statusCounts[status]; !ok {
+			count = new(int64)
+			statusCounts[status] = count
+		}
+		statusCountsMu.Unlock()
+	}
+	atomic.AddInt64(count, 1)
+}
@@ -0,0 +1,67 @@
+package wrapperrors
+
+import "errors"
+
+type temporaryError interface {
+	Temporary() bool
+}
+
+type timeoutError interface {
+	Timeout() bool
+}
+
+func (e *wrapper) WithTemporary(temporary bool) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithTemporary(temporary) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.temporary = &temporary
+	return e
+}
+
+func (e *wrapper) WithTimeout(timeout bool) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithTimeout(timeout) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.timeout = &timeout
+	return e
+}
+
+// Temporary reports whether the error is temporary. Unless set with WithTemporary, the value is
+// inherited from the first cause implementing Temporary.
+func (e *wrapper) Temporary() bool {
+	unlock := e.rlock()
+	temporary, causes := e.temporary, e.causes
+	unlock()
+	if temporary != nil {
+		return *temporary
+	}
+	for _, cause := range causes {
+		var target temporaryError
+		if errors.As(cause, &target) {
+			return target.Temporary()
+		}
+	}
+	return false
+}
+
+// Timeout reports whether the error is a timeout. Unless set with WithTimeout, the value is
+// inherited from the first cause implementing Timeout.
+func (e *wrapper) Timeout() bool {
+	unlock := e.rlock()
+	timeout, causes := e.timeout, e.causes
+	unlock()
+	if timeout != nil {
+		return *timeout
+	}
+	for _, cause := range causes {
+		var target timeoutError
+		if errors.As(cause, &target) {
+			return target.Timeout()
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package wrapperrors
+
+import "strings"
+
+// WithOperation records the name of the operation that failed, e.g. "users.Create". Each layer
+// propagating the error can record its own operation.
+func (e *wrapper) WithOperation(op string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithOperation(op) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.ops = append(e.ops, op)
+	return e
+}
+
+// Operations retrieves the operations recorded along the cause chain of a given error, from the
+// outermost to the innermost.
+func Operations(e error) []string {
+	ops := make([]string, 0)
+	visitChain(e, func(err error) bool {
+		if wp, ok := asWrapper(err); ok {
+			defer wp.rlock()()
+			for i := len(wp.ops) - 1; i >= 0; i-- {
+				ops = append(ops, wp.ops[i])
+			}
+		}
+		return true
+	})
+	return ops
+}
+
+func operationsString(e error) string {
+	return strings.Join(Operations(e), " > ")
+}
@@ -0,0 +1,48 @@
+package wrapperrors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	pooling int32
+
+	wrapperPool = sync.Pool{
+		New: func() interface{} {
+			return &wrapper{RWMutex: &sync.RWMutex{}}
+		},
+	}
+)
+
+// SetPooling toggles recycling of wrappers. When enabled, errors handed to Release are reset and
+// reused by later constructors, which saves allocations in hot paths. Using an error after releasing
+// it is a bug, which is why pooling is disabled by default.
+func SetPooling(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&pooling, mode)
+}
+
+// Release resets every field of the given error and hands it back to the pool for reuse. It does
+// nothing unless pooling is enabled, and definitions created by Define are never released.
+func Release(e ErrorWrapper) {
+	wp, ok := asWrapper(e)
+	if !ok || wp.RWMutex == nil || atomic.LoadInt32(&pooling) == 0 {
+		return
+	}
+	mu := wp.RWMutex
+	mu.Lock()
+	*wp = wrapper{RWMutex: mu}
+	mu.Unlock()
+	wrapperPool.Put(wp)
+}
+
+func allocWrapper() *wrapper {
+	if atomic.LoadInt32(&pooling) == 1 {
+		return wrapperPool.Get().(*wrapper)
+	}
+	return &wrapper{RWMutex: &sync.RWMutex{}}
+}
@@ -0,0 +1,66 @@
+package wrapperrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiReset  = "\x1b[0m"
+)
+
+// Pretty renders the error for humans, e.g. in command-line tools: one line per code, message and
+// status, followed by the causes indented beneath. When color is true, the code is rendered in red and
+// the status in yellow using ANSI escape codes; whether the output is a terminal is left to the caller.
+// A cause repeated along its own chain is rendered with its code only.
+func (e *wrapper) Pretty(color bool) string {
+	if e == nil {
+		return ""
+	}
+	return strings.Join(e.pretty(color, "", make(map[*wrapper]bool)), "\n")
+}
+
+func (e *wrapper) pretty(color bool, indent string, seen map[*wrapper]bool) []string {
+	seen[e] = true
+	defer delete(seen, e)
+	paint := func(ansi, text string) string {
+		if !color {
+			return text
+		}
+		return ansi + text + ansiReset
+	}
+	unlock := e.rlock()
+	lines := make([]string, 0, 4)
+	if len(e.code) > 0 {
+		lines = append(lines, indent+"code: "+paint(ansiRed, strings.Join(e.code, "; ")))
+	}
+	if len(e.message) > 0 {
+		lines = append(lines, indent+"message: "+strings.Join(e.message, "; "))
+	}
+	if len(e.status) > 0 {
+		status := make([]string, len(e.status))
+		for i, v := range e.status {
+			status[i] = fmt.Sprintf("%d %s", v.code, v.message)
+		}
+		lines = append(lines, indent+"status: "+paint(ansiYellow, strings.Join(status, ", ")))
+	}
+	causes := e.causes
+	unlock()
+	if len(causes) > 0 {
+		lines = append(lines, indent+"cause:")
+	}
+	for _, cause := range causes {
+		wp, ok := asWrapper(cause)
+		switch {
+		case !ok:
+			lines = append(lines, indent+"  "+cause.Error())
+		case seen[wp]:
+			lines = append(lines, indent+"  code: "+paint(ansiRed, Code(wp)))
+		default:
+			lines = append(lines, wp.pretty(color, indent+"  ", seen)...)
+		}
+	}
+	return lines
+}
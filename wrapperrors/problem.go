@@ -0,0 +1,63 @@
+package wrapperrors
+
+import "sync"
+
+var (
+	typeURIs   = make(map[string]string)
+	typeURIsMu sync.RWMutex
+)
+
+// SetTypeURI maps a code to the URI of its documentation, used as the type member of problem details.
+func SetTypeURI(code, uri string) {
+	typeURIsMu.Lock()
+	defer typeURIsMu.Unlock()
+	typeURIs[code] = uri
+}
+
+func typeURI(codes []string) string {
+	typeURIsMu.RLock()
+	defer typeURIsMu.RUnlock()
+	if uri, ok := lookupCatalog(typeURIs, codes); ok {
+		return uri
+	}
+	return "about:blank"
+}
+
+// ToProblem9457 renders the given error as an RFC 9457 problem details object. Besides the standard
+// members (type, title, status, detail and instance) the wrapper's code, cause and hint are added as
+// extension members, as well as each field under its own key unless it collides with one of those
+// members. The type is the URI registered with SetTypeURI for the most specific code, or
+// "about:blank". The detail holds the public messages set with WithPublicMessage, or a generic message
+// when there is none, and the instance holds the resource set with WithResource.
+func ToProblem9457(err error) map[string]interface{} {
+	wp, ok := asWrapper(err)
+	if !ok {
+		wp = unknownError(err)
+	}
+	status := GetStatusCode(wp)
+	problem := map[string]interface{}{
+		"type":   typeURI(wp.codes()),
+		"title":  getStatusText(status),
+		"status": status,
+		"detail": wp.PublicError(),
+	}
+	defer wp.rlock()()
+	if len(wp.code) > 0 {
+		problem["code"] = wp.code
+	}
+	if len(wp.causes) > 0 {
+		problem["cause"] = wp.causeString()
+	}
+	if hint := wp.resolveHint(); hint != "" {
+		problem["hint"] = hint
+	}
+	if resource := wp.resolveResource(); resource != "" {
+		problem["instance"] = resource
+	}
+	for key, value := range wp.fields {
+		if _, exists := problem[key]; !exists {
+			problem[key] = value
+		}
+	}
+	return problem
+}
@@ -0,0 +1,66 @@
+package wrapperrors
+
+import "errors"
+
+// PublicWrapper is an exported read-only snapshot of an error created by this package. It can be
+// recovered from any error chain with errors.As or As.
+type PublicWrapper struct {
+	wp *wrapper
+}
+
+// As recovers a PublicWrapper snapshot of the first wrapper found in the chain of a given error.
+func As(err error) (*PublicWrapper, bool) {
+	var public *PublicWrapper
+	if errors.As(err, &public) {
+		return public, true
+	}
+
+	return nil, false
+}
+
+// As allows errors.As to recover a *PublicWrapper from the error.
+func (e *wrapper) As(target interface{}) bool {
+	if public, ok := target.(**PublicWrapper); ok && e != nil {
+		*public = &PublicWrapper{wp: e.clone()}
+		return true
+	}
+	return false
+}
+
+func (p *PublicWrapper) Error() string {
+	return p.wp.Error()
+}
+
+// Unwrap returns the causes of the snapshot.
+func (p *PublicWrapper) Unwrap() []error {
+	return p.Causes()
+}
+
+// Code returns the codes of the error.
+func (p *PublicWrapper) Code() []string {
+	return append([]string(nil), p.wp.code...)
+}
+
+// Message returns the messages of the error.
+func (p *PublicWrapper) Message() []string {
+	return append([]string(nil), p.wp.message...)
+}
+
+// Status returns the statuses of the error.
+func (p *PublicWrapper) Status() []StatusEntry {
+	status := make([]StatusEntry, len(p.wp.status))
+	for i, v := range p.wp.status {
+		status[i] = StatusEntry{Message: v.message, Code: v.code}
+	}
+	return status
+}
+
+// Causes returns the causes of the error.
+func (p *PublicWrapper) Causes() []error {
+	return append([]error(nil), p.wp.causes...)
+}
+
+// Fields returns the metadata fields of the error.
+func (p *PublicWrapper) Fields() map[string]interface{} {
+	return Fields(p.wp)
+}
@@ -0,0 +1,30 @@
+package wrapperrors
+
+// WithResource sets the locator of the resource the error refers to, e.g. "/cars/123".
+func (e *wrapper) WithResource(resource string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithResource(resource) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.resource = resource
+	return e
+}
+
+// Resource retrieves the resource locator of a given error. Unless set with WithResource, it is
+// inherited from the first error in the cause chain carrying one.
+func Resource(e error) string {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		return wp.resolveResource()
+	}
+
+	return ""
+}
+
+func (e wrapper) resolveResource() string {
+	if e.resource != "" {
+		return e.resource
+	}
+	return e.inheritString(func(wp *wrapper) string { return wp.resource })
+}
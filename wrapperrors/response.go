@@ -0,0 +1,51 @@
+package wrapperrors
+
+// ErrorResponse is the typed representation of an error, holding the same data as Json, internal
+// messages and causes included. The body sent to API clients is the one returned by ResponseBody.
+type ErrorResponse struct {
+	Code     []string               `json:"code,omitempty"`
+	Message  []string               `json:"message,omitempty"`
+	Status   []StatusEntry          `json:"status,omitempty"`
+	Cause    string                 `json:"cause,omitempty"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+	TraceID  string                 `json:"trace_id,omitempty"`
+	Resource string                 `json:"resource,omitempty"`
+	Hint     string                 `json:"hint,omitempty"`
+}
+
+// StatusEntry is a status of an ErrorResponse.
+type StatusEntry struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// Response returns the typed representation of the error, holding the same data as Json.
+func (e *wrapper) Response() ErrorResponse {
+	if e == nil {
+		return ErrorResponse{}
+	}
+	defer e.rlock()()
+	response := ErrorResponse{
+		Code:     append([]string(nil), e.code...),
+		Message:  e.responseMessage(),
+		Cause:    e.causeString(),
+		Fields:   copyFields(e.fields),
+		TraceID:  e.resolveTraceID(),
+		Resource: e.resolveResource(),
+		Hint:     e.resolveHint(),
+	}
+	for _, status := range e.status {
+		response.Status = append(response.Status, StatusEntry{Message: status.message, Code: status.code})
+	}
+	return response
+}
+
+// responseMessage returns the messages returned to clients, localized when a locale is set.
+func (e wrapper) responseMessage() []string {
+	if e.locale != "" {
+		if message := localizedMessage(&e, e.locale); message != "" {
+			return []string{message}
+		}
+	}
+	return append([]string(nil), e.message...)
+}
@@ -0,0 +1,46 @@
+package wrapperrors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	safeStringMode int32
+
+	sensitiveFields   = make(map[string]bool)
+	sensitiveFieldsMu sync.RWMutex
+)
+
+// SetSafeStringMode toggles the safe mode of String(). When enabled, String() omits the causes and
+// the fields marked with MarkSensitive, keeping its output free of personal data.
+func SetSafeStringMode(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&safeStringMode, mode)
+}
+
+func isSafeStringMode() bool {
+	return atomic.LoadInt32(&safeStringMode) == 1
+}
+
+// MarkSensitive marks a field key as sensitive, so it is omitted from String() in safe mode.
+func MarkSensitive(key string) {
+	sensitiveFieldsMu.Lock()
+	defer sensitiveFieldsMu.Unlock()
+	sensitiveFields[key] = true
+}
+
+func safeFields(fields map[string]interface{}) map[string]interface{} {
+	sensitiveFieldsMu.RLock()
+	defer sensitiveFieldsMu.RUnlock()
+	safe := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if !sensitiveFields[key] {
+			safe[key] = value
+		}
+	}
+	return safe
+}
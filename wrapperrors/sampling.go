@@ -0,0 +1,64 @@
+package wrapperrors
+
+import (
+	"sync"
+	"time"
+)
+
+type tokenBucket struct {
+	perSecond float64
+	tokens    float64
+	last      time.Time
+}
+
+var (
+	samplers   = make(map[string]*tokenBucket)
+	samplersMu sync.Mutex
+)
+
+// SetSampleRate limits how many errors with the given code ShouldLog allows per second, using a token
+// bucket that allows bursts of up to perSecond errors. A rate lower than or equal to zero removes the limit.
+func SetSampleRate(code string, perSecond int) {
+	samplersMu.Lock()
+	defer samplersMu.Unlock()
+	if perSecond <= 0 {
+		delete(samplers, code)
+		return
+	}
+	samplers[code] = &tokenBucket{
+		perSecond: float64(perSecond),
+		tokens:    float64(perSecond),
+		last:      now(),
+	}
+}
+
+// ShouldLog reports whether the given error should be logged according to the sample rate of its code.
+// The most specific code with a sample rate is used. Errors without a sample rate are always logged.
+func ShouldLog(e error) bool {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return true
+	}
+	codes := wp.codes()
+	samplersMu.Lock()
+	defer samplersMu.Unlock()
+	for i := len(codes) - 1; i >= 0; i-- {
+		if bucket, ok := samplers[codes[i]]; ok {
+			return bucket.take(now())
+		}
+	}
+	return true
+}
+
+func (b *tokenBucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.last).Seconds() * b.perSecond
+	if b.tokens > b.perSecond {
+		b.tokens = b.perSecond
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
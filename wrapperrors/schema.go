@@ -0,0 +1,47 @@
+package wrapperrors
+
+// OpenAPISchema returns the JSON Schema of the error representation produced by MarshalJSON, suitable
+// for embedding as a component of an OpenAPI specification. A new map is returned on every call, so it
+// can be modified freely.
+func OpenAPISchema() map[string]interface{} {
+	stringArray := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": description,
+		}
+	}
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"code", "message", "status"},
+		"properties": map[string]interface{}{
+			"code":    stringArray("Error codes, outermost first."),
+			"message": stringArray("Error messages, outermost first."),
+			"status": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"message", "code"},
+					"properties": map[string]interface{}{
+						"message": map[string]interface{}{"type": "string"},
+						"code":    map[string]interface{}{"type": "integer"},
+					},
+				},
+				"description": "HTTP statuses, outermost first.",
+			},
+			"cause": map[string]interface{}{
+				"type":        "string",
+				"description": "Text of the underlying causes.",
+			},
+			"fields": map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": true,
+				"description":          "Structured context attached to the error.",
+			},
+			"trace_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Trace ID of the request that failed.",
+			},
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package wrapperrors
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+)
+
+var (
+	NotFoundError   = Define("not_found", http.StatusNotFound)
+	ConflictError   = Define("conflict", http.StatusConflict)
+	ConstraintError = Define("constraint_violation", http.StatusUnprocessableEntity)
+)
+
+// sqlStates maps Postgres SQLSTATE codes to definitions.
+var sqlStates = map[string]ErrorWrapper{
+	"23505": ConflictError,   // unique_violation
+	"23503": ConstraintError, // foreign_key_violation
+	"23502": ConstraintError, // not_null_violation
+	"23514": ConstraintError, // check_violation
+}
+
+// sqlStateError is implemented by driver errors exposing a SQLSTATE, such as *pq.Error and
+// *pgconn.PgError.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// FromSQL translates a database error into an error wrapping it. Known SQLSTATE codes map to
+// ConflictError or ConstraintError and are kept in the "sqlstate" field, sql.ErrNoRows maps to
+// NotFoundError and anything else becomes an InternalError.
+func FromSQL(err error) ErrorWrapper {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		definition, _ := asWrapper(NotFoundError)
+		return created(definition.fromDefinition(err))
+	}
+	definition, _ := asWrapper(InternalError)
+	var stateErr sqlStateError
+	if !errors.As(err, &stateErr) {
+		return created(definition.fromDefinition(err))
+	}
+	state := stateErr.SQLState()
+	if def, ok := sqlStates[state]; ok {
+		definition, _ = asWrapper(def)
+	}
+	wp := definition.fromDefinition(err)
+	wp.WithField("sqlstate", state)
+	return created(wp)
+}
@@ -0,0 +1,152 @@
+package wrapperrors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const defaultStackDepth = 32
+
+var (
+	stackDepth int32 = defaultStackDepth
+
+	// stackStatusClass holds the status class (e.g. 5 for "5xx") whose errors get a stack trace.
+	// Zero means that every error gets a stack trace at creation.
+	stackStatusClass int32
+
+	packagePrefix = reflect.TypeOf(wrapper{}).PkgPath() + "."
+)
+
+// SetStackCaptureForStatusClass restricts stack capture to errors whose status belongs to the given
+// class, e.g. "5xx". Because the status is usually set after creation, the stack is captured on the
+// first WithStatus call with a matching status. An empty or unrecognized class captures the stack of
+// every error at creation, which is the default.
+func SetStackCaptureForStatusClass(class string) {
+	var digit int32
+	if len(class) == 3 && class[0] >= '1' && class[0] <= '9' && strings.ToLower(class[1:]) == "xx" {
+		digit = int32(class[0] - '0')
+	}
+	atomic.StoreInt32(&stackStatusClass, digit)
+}
+
+// SetStackDepth caps the number of frames captured per stack trace. A depth lower than or equal to
+// zero restores the default of 32 frames.
+func SetStackDepth(depth int) {
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+	atomic.StoreInt32(&stackDepth, int32(depth))
+}
+
+// StackTrace retrieves the stack trace captured for a given error, one frame per function and
+// location pair. It returns an empty string when no stack has been captured.
+func StackTrace(e error) string {
+	wp, ok := asWrapper(e)
+	if !ok {
+		return ""
+	}
+	unlock := wp.rlock()
+	stack := wp.stack
+	unlock()
+	if len(stack) == 0 {
+		return ""
+	}
+	buff := strings.Builder{}
+	frames := runtime.CallersFrames(stack)
+	inPackage := true
+	for {
+		frame, more := frames.Next()
+		if inPackage && strings.HasPrefix(frame.Function, packagePrefix) {
+			if !more {
+				break
+			}
+			continue
+		}
+		inPackage = false
+		buff.WriteString(fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+	return buff.String()
+}
+
+// Format implements fmt.Formatter. The %+v verb writes the error followed by the operations and the
+// stack traces recorded along its cause chain, while %v, %s and %q write the error as returned by
+// Error. Other verbs are reported as fmt does, e.g. %!d(...), with the error text between parentheses.
+func (e *wrapper) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		io.WriteString(s, e.Error())
+		if verb == 'v' && s.Flag('+') {
+			if ops := operationsString(e); ops != "" {
+				io.WriteString(s, "\nop: "+ops)
+			}
+			visitChain(e, func(err error) bool {
+				if trace := StackTrace(err); trace != "" {
+					io.WriteString(s, "\n"+strings.TrimSuffix(trace, "\n"))
+				}
+				return true
+			})
+		}
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(%s)", verb, e.Error())
+	}
+}
+
+func captureStackOnCreation() bool {
+	return atomic.LoadInt32(&stackStatusClass) == 0
+}
+
+func captureStackForStatus(status int) bool {
+	class := atomic.LoadInt32(&stackStatusClass)
+	return class != 0 && status/100 == int(class)
+}
+
+func callers() []uintptr {
+	pcs := make([]uintptr, atomic.LoadInt32(&stackDepth))
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// trimStack drops the outermost frames that stack shares with the stacks captured along the cause
+// chain, keeping only the frames above the point where the cause was created.
+func trimStack(stack []uintptr, cause error) []uintptr {
+	trimmed := stack
+	visitChain(cause, func(err error) bool {
+		if wp, ok := asWrapper(err); ok {
+			unlock := wp.rlock()
+			causeStack := wp.stack
+			unlock()
+			if own := trimCommonFrames(stack, causeStack); len(causeStack) > 0 && len(own) < len(trimmed) {
+				trimmed = own
+			}
+		}
+		return true
+	})
+	return trimmed
+}
+
+func trimCommonFrames(stack, cause []uintptr) []uintptr {
+	i, j := len(stack), len(cause)
+	for i > 0 && j > 0 && frameKey(stack[i-1]) == frameKey(cause[j-1]) {
+		i--
+		j--
+	}
+	return stack[:i:i]
+}
+
+func frameKey(pc uintptr) string {
+	fn := runtime.FuncForPC(pc - 1)
+	if fn == nil {
+		return ""
+	}
+	file, line := fn.FileLine(pc - 1)
+	return fmt.Sprintf("%s %s:%d", fn.Name(), file, line)
+}
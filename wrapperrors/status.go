@@ -0,0 +1,89 @@
+package wrapperrors
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// StatusCode is a type-checked HTTP status accepted by WithStatusCode.
+type StatusCode int
+
+const (
+	StatusBadRequest          StatusCode = http.StatusBadRequest
+	StatusUnauthorized        StatusCode = http.StatusUnauthorized
+	StatusForbidden           StatusCode = http.StatusForbidden
+	StatusNotFound            StatusCode = http.StatusNotFound
+	StatusMethodNotAllowed    StatusCode = http.StatusMethodNotAllowed
+	StatusConflict            StatusCode = http.StatusConflict
+	StatusGone                StatusCode = http.StatusGone
+	StatusPreconditionFailed  StatusCode = http.StatusPreconditionFailed
+	StatusUnprocessableEntity StatusCode = http.StatusUnprocessableEntity
+	StatusTooManyRequests     StatusCode = http.StatusTooManyRequests
+	StatusInternalServerError StatusCode = http.StatusInternalServerError
+	StatusNotImplemented      StatusCode = http.StatusNotImplemented
+	StatusBadGateway          StatusCode = http.StatusBadGateway
+	StatusServiceUnavailable  StatusCode = http.StatusServiceUnavailable
+	StatusGatewayTimeout      StatusCode = http.StatusGatewayTimeout
+)
+
+// Code returns the numeric value of the status.
+func (s StatusCode) Code() int {
+	return int(s)
+}
+
+// Text returns the canonical HTTP text of the status, or its numeric value when there is none.
+func (s StatusCode) Text() string {
+	if text := http.StatusText(int(s)); text != "" {
+		return text
+	}
+	return strconv.Itoa(int(s))
+}
+
+// WithStatusCode adds the given status with its canonical text.
+func (e *wrapper) WithStatusCode(status StatusCode) ErrorWrapper {
+	return e.WithStatusText(status.Code(), status.Text())
+}
+
+// IsBadRequest reports whether a given error has a 400 status code.
+func IsBadRequest(e error) bool {
+	return hasStatus(e, http.StatusBadRequest)
+}
+
+// IsUnauthorized reports whether a given error has a 401 status code.
+func IsUnauthorized(e error) bool {
+	return hasStatus(e, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether a given error has a 403 status code.
+func IsForbidden(e error) bool {
+	return hasStatus(e, http.StatusForbidden)
+}
+
+// IsNotFound reports whether a given error has a 404 status code.
+func IsNotFound(e error) bool {
+	return hasStatus(e, http.StatusNotFound)
+}
+
+// IsConflict reports whether a given error has a 409 status code.
+func IsConflict(e error) bool {
+	return hasStatus(e, http.StatusConflict)
+}
+
+// IsTooManyRequests reports whether a given error has a 429 status code.
+func IsTooManyRequests(e error) bool {
+	return hasStatus(e, http.StatusTooManyRequests)
+}
+
+// IsServiceUnavailable reports whether a given error has a 503 status code.
+func IsServiceUnavailable(e error) bool {
+	return hasStatus(e, http.StatusServiceUnavailable)
+}
+
+// hasStatus reports whether a given error was created by this package and its status code, as returned
+// by GetStatusCode, is the given one.
+func hasStatus(e error, status int) bool {
+	if _, ok := asWrapper(e); !ok {
+		return false
+	}
+	return GetStatusCode(e) == status
+}
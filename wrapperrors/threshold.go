@@ -0,0 +1,82 @@
+package wrapperrors
+
+import (
+	"sync"
+	"time"
+)
+
+type slidingWindow struct {
+	perMinute int
+	events    []time.Time
+}
+
+var (
+	thresholds   = make(map[string]*slidingWindow)
+	thresholdsMu sync.RWMutex
+)
+
+// SetThreshold sets how many errors with the given code may be created within a sliding minute before
+// OverThreshold reports it. A threshold lower than or equal to zero removes it.
+func SetThreshold(code string, perMinute int) {
+	thresholdsMu.Lock()
+	defer thresholdsMu.Unlock()
+	if perMinute <= 0 {
+		delete(thresholds, code)
+		return
+	}
+	thresholds[code] = &slidingWindow{perMinute: perMinute}
+}
+
+// OverThreshold reports whether more errors with the given code than its threshold were created within
+// the last minute. Codes without a threshold are never over it.
+func OverThreshold(code string) bool {
+	thresholdsMu.Lock()
+	defer thresholdsMu.Unlock()
+	window, ok := thresholds[code]
+	if !ok {
+		return false
+	}
+	window.prune(now())
+	return len(window.events) > window.perMinute
+}
+
+// countThreshold records the creation of an error in the windows of its codes. Since it runs on every
+// creation, the write lock is only taken when one of the codes has a threshold.
+func countThreshold(codes []string) {
+	if !hasThreshold(codes) {
+		return
+	}
+	thresholdsMu.Lock()
+	defer thresholdsMu.Unlock()
+	t := now()
+	for _, code := range codes {
+		if window, ok := thresholds[code]; ok {
+			window.prune(t)
+			window.events = append(window.events, t)
+		}
+	}
+}
+
+// hasThreshold reports whether any of the given codes has a threshold.
+func hasThreshold(codes []string) bool {
+	thresholdsMu.RLock()
+	defer thresholdsMu.RUnlock()
+	if len(thresholds) == 0 {
+		return false
+	}
+	for _, code := range codes {
+		if _, ok := thresholds[code]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *slidingWindow) prune(now time.Time) {
+	start := now.Add(-time.Minute)
+	i := 0
+	for i < len(w.events) && !w.events[i].After(start) {
+		i++
+	}
+	w.events = w.events[i:]
+}
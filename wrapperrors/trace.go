@@ -0,0 +1,29 @@
+package wrapperrors
+
+func (e *wrapper) WithTraceID(id string) ErrorWrapper {
+	if e.RWMutex == nil {
+		return e.instantiate(func(wp *wrapper) { wp.WithTraceID(id) })
+	}
+	e.Lock()
+	defer e.Unlock()
+	e.traceID = id
+	return e
+}
+
+// TraceID retrieves the correlation ID of a given error. Unless set with WithTraceID, it is inherited
+// from the first error in the cause chain carrying one.
+func TraceID(e error) string {
+	if wp, ok := asWrapper(e); ok {
+		defer wp.rlock()()
+		return wp.resolveTraceID()
+	}
+
+	return ""
+}
+
+func (e wrapper) resolveTraceID() string {
+	if e.traceID != "" {
+		return e.traceID
+	}
+	return e.inheritString(func(wp *wrapper) string { return wp.traceID })
+}
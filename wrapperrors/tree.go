@@ -0,0 +1,44 @@
+package wrapperrors
+
+// TreeMap returns the same data as Json, except that causes created by this package are rendered as
+// nested maps instead of strings. A cause repeated along its own chain is rendered with its code only.
+func (e *wrapper) TreeMap() map[string]interface{} {
+	if e == nil {
+		return make(map[string]interface{})
+	}
+	return e.treeMap(make(map[*wrapper]bool))
+}
+
+func (e *wrapper) treeMap(seen map[*wrapper]bool) map[string]interface{} {
+	seen[e] = true
+	defer delete(seen, e)
+	unlock := e.rlock()
+	shallow := *e
+	causes := shallow.causes
+	shallow.causes = nil
+	m := shallow.toMap()
+	if traceID := e.resolveTraceID(); traceID != "" {
+		m["trace_id"] = traceID
+	}
+	unlock()
+	rendered := make([]interface{}, 0, len(causes))
+	for _, cause := range causes {
+		wp, ok := asWrapper(cause)
+		switch {
+		case !ok:
+			rendered = append(rendered, cause.Error())
+		case seen[wp]:
+			rendered = append(rendered, map[string]interface{}{"code": wp.codes()})
+		default:
+			rendered = append(rendered, wp.treeMap(seen))
+		}
+	}
+	switch len(rendered) {
+	case 0:
+	case 1:
+		m["cause"] = rendered[0]
+	default:
+		m["cause"] = rendered
+	}
+	return m
+}
@@ -0,0 +1,251 @@
+package wrapperrors
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ValidationFailed is the definition used by validation errors.
+var ValidationFailed = Define("validation_error", http.StatusUnprocessableEntity)
+
+// ValidationError accumulates field-level validation messages on top of a wrapper error. It can be
+// recovered from an error chain with errors.As.
+type ValidationError struct {
+	*wrapper
+	fieldErrors map[string][]string
+}
+
+// NewValidationError creates an empty validation error with the validation_error code and status 422.
+func NewValidationError() *ValidationError {
+	wp, _ := asWrapper(ValidationFailed.FromDefinition(nil))
+	return &ValidationError{
+		wrapper:     wp,
+		fieldErrors: make(map[string][]string),
+	}
+}
+
+// AddFieldError adds a validation message to the given field.
+func (v *ValidationError) AddFieldError(field, message string) *ValidationError {
+	v.Lock()
+	defer v.Unlock()
+	v.fieldErrors[field] = append(v.fieldErrors[field], message)
+	return v
+}
+
+// HasFieldErrors reports whether any field error has been added.
+func (v *ValidationError) HasFieldErrors() bool {
+	defer v.rlock()()
+	return len(v.fieldErrors) > 0
+}
+
+// FieldErrors returns a copy of the messages grouped by field.
+func (v *ValidationError) FieldErrors() map[string][]string {
+	defer v.rlock()()
+	fieldErrors := make(map[string][]string, len(v.fieldErrors))
+	for field, messages := range v.fieldErrors {
+		fieldErrors[field] = append([]string(nil), messages...)
+	}
+	return fieldErrors
+}
+
+// Json returns the map representation of the error, where fields maps each field name to its messages
+// instead of holding the metadata fields.
+func (v *ValidationError) Json() map[string]interface{} {
+	m := v.wrapper.Json()
+	m["fields"] = v.FieldErrors()
+	return m
+}
+
+// fieldErrorsMap returns the messages grouped by field as a fields map.
+func (v *ValidationError) fieldErrorsMap() map[string]interface{} {
+	fieldErrors := v.FieldErrors()
+	fields := make(map[string]interface{}, len(fieldErrors))
+	for field, messages := range fieldErrors {
+		fields[field] = messages
+	}
+	return fields
+}
+
+// Response returns the typed representation of the error, where fields maps each field name to its
+// messages.
+func (v *ValidationError) Response() ErrorResponse {
+	response := v.wrapper.Response()
+	response.Fields = v.fieldErrorsMap()
+	return response
+}
+
+// EncodeJSON streams the error as the wrapper's EncodeJSON does, writing the field errors as fields.
+func (v *ValidationError) EncodeJSON(w io.Writer) error {
+	return v.wrapper.encodeJSON(w, v.FieldErrors())
+}
+
+// MarshalJSON encodes the error as the wrapper's MarshalJSON does, holding the field errors as fields.
+func (v *ValidationError) MarshalJSON() ([]byte, error) {
+	encoded := v.wrapper.jsonError()
+	encoded.Fields = v.fieldErrorsMap()
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON reconstructs an error encoded by MarshalJSON, restoring its field errors.
+func (v *ValidationError) UnmarshalJSON(data []byte) error {
+	if v.wrapper == nil {
+		v.wrapper = &wrapper{}
+	}
+	if err := v.wrapper.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	v.Lock()
+	defer v.Unlock()
+	v.fieldErrors = make(map[string][]string, len(v.fields))
+	for field, value := range v.fields {
+		messages, _ := value.([]interface{})
+		for _, message := range messages {
+			if message, ok := message.(string); ok {
+				v.fieldErrors[field] = append(v.fieldErrors[field], message)
+			}
+		}
+	}
+	v.fields = nil
+	return nil
+}
+
+// Clone returns a copy of the validation error holding a copy of its field errors.
+func (v *ValidationError) Clone() ErrorWrapper {
+	return &ValidationError{wrapper: v.wrapper.clone(), fieldErrors: v.FieldErrors()}
+}
+
+// Detach returns a copy of the validation error without its causes nor debug-only query, keeping its
+// field errors.
+func (v *ValidationError) Detach() ErrorWrapper {
+	wp, _ := asWrapper(v.wrapper.Detach())
+	return &ValidationError{wrapper: wp, fieldErrors: v.FieldErrors()}
+}
+
+// The builders are overridden so that chaining them keeps the field errors.
+
+func (v *ValidationError) WithMessage(message string) ErrorWrapper {
+	v.wrapper.WithMessage(message)
+	return v
+}
+
+func (v *ValidationError) WithMessagef(format string, args ...interface{}) ErrorWrapper {
+	v.wrapper.WithMessagef(format, args...)
+	return v
+}
+
+func (v *ValidationError) WithPublicMessage(message string) ErrorWrapper {
+	v.wrapper.WithPublicMessage(message)
+	return v
+}
+
+func (v *ValidationError) WithCode(code string) ErrorWrapper {
+	v.wrapper.WithCode(code)
+	return v
+}
+
+func (v *ValidationError) WithStatus(status int) ErrorWrapper {
+	v.wrapper.WithStatus(status)
+	return v
+}
+
+func (v *ValidationError) WithStatusText(status int, text string) ErrorWrapper {
+	v.wrapper.WithStatusText(status, text)
+	return v
+}
+
+func (v *ValidationError) WithStatusCode(status StatusCode) ErrorWrapper {
+	v.wrapper.WithStatusCode(status)
+	return v
+}
+
+func (v *ValidationError) WithCause(err error) ErrorWrapper {
+	v.wrapper.WithCause(err)
+	return v
+}
+
+func (v *ValidationError) WithCauses(errs ...error) ErrorWrapper {
+	v.wrapper.WithCauses(errs...)
+	return v
+}
+
+func (v *ValidationError) WithField(key string, value interface{}) ErrorWrapper {
+	v.wrapper.WithField(key, value)
+	return v
+}
+
+func (v *ValidationError) WithTemporary(temporary bool) ErrorWrapper {
+	v.wrapper.WithTemporary(temporary)
+	return v
+}
+
+func (v *ValidationError) WithTimeout(timeout bool) ErrorWrapper {
+	v.wrapper.WithTimeout(timeout)
+	return v
+}
+
+func (v *ValidationError) WithUserFacing(userFacing bool) ErrorWrapper {
+	v.wrapper.WithUserFacing(userFacing)
+	return v
+}
+
+func (v *ValidationError) WithBreakerTrip(trip bool) ErrorWrapper {
+	v.wrapper.WithBreakerTrip(trip)
+	return v
+}
+
+func (v *ValidationError) WithFingerprint(fingerprint string) ErrorWrapper {
+	v.wrapper.WithFingerprint(fingerprint)
+	return v
+}
+
+func (v *ValidationError) WithDeadline(deadline time.Time) ErrorWrapper {
+	v.wrapper.WithDeadline(deadline)
+	return v
+}
+
+func (v *ValidationError) WithHint(hint string) ErrorWrapper {
+	v.wrapper.WithHint(hint)
+	return v
+}
+
+func (v *ValidationError) WithResource(resource string) ErrorWrapper {
+	v.wrapper.WithResource(resource)
+	return v
+}
+
+func (v *ValidationError) WithLevel(level Level) ErrorWrapper {
+	v.wrapper.WithLevel(level)
+	return v
+}
+
+func (v *ValidationError) WithSeverity(severity Severity) ErrorWrapper {
+	v.wrapper.WithSeverity(severity)
+	return v
+}
+
+func (v *ValidationError) WithQuery(query string, args ...interface{}) ErrorWrapper {
+	v.wrapper.WithQuery(query, args...)
+	return v
+}
+
+func (v *ValidationError) WithHeader(key, value string) ErrorWrapper {
+	v.wrapper.WithHeader(key, value)
+	return v
+}
+
+func (v *ValidationError) WithLocale(lang string) ErrorWrapper {
+	v.wrapper.WithLocale(lang)
+	return v
+}
+
+func (v *ValidationError) WithTraceID(id string) ErrorWrapper {
+	v.wrapper.WithTraceID(id)
+	return v
+}
+
+func (v *ValidationError) WithOperation(op string) ErrorWrapper {
+	v.wrapper.WithOperation(op)
+	return v
+}
@@ -0,0 +1,54 @@
+// Package validatorerrors provides go-playground/validator integration for wrapperrors.
+package validatorerrors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+	"github.com/go-playground/validator/v10"
+)
+
+var tagMessages = map[string]string{
+	"required": "is required",
+	"email":    "must be a valid email address",
+	"url":      "must be a valid URL",
+	"uuid":     "must be a valid UUID",
+	"min":      "must be at least %s",
+	"max":      "must be at most %s",
+	"len":      "must have a length of %s",
+	"gt":       "must be greater than %s",
+	"gte":      "must be greater than or equal to %s",
+	"lt":       "must be less than %s",
+	"lte":      "must be less than or equal to %s",
+	"oneof":    "must be one of %s",
+}
+
+// FromValidator converts the validator.ValidationErrors found in the chain of a given error into a
+// *wrapperrors.ValidationError, adding one field error per violation with a message describing the
+// failed tag. Any other error becomes an InternalError holding it as cause.
+func FromValidator(err error) wrapperrors.ErrorWrapper {
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return wrapperrors.InternalError.FromDefinition(err)
+	}
+	validationError := wrapperrors.NewValidationError()
+	for _, fieldError := range validationErrors {
+		validationError.AddFieldError(fieldError.Field(), message(fieldError))
+	}
+	return validationError
+}
+
+func message(fieldError validator.FieldError) string {
+	format, ok := tagMessages[fieldError.Tag()]
+	if !ok {
+		return fmt.Sprintf("failed on the %s validation", fieldError.Tag())
+	}
+	if fieldError.Param() == "" {
+		return format
+	}
+	return fmt.Sprintf(format, fieldError.Param())
+}
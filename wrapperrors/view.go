@@ -0,0 +1,49 @@
+package wrapperrors
+
+import "io"
+
+// ErrorView is a read-only view of an error. Unlike ErrorWrapper, it exposes no builder, so it can be
+// handed to untrusted code while the error itself stays private.
+type ErrorView interface {
+	Error() string
+	PublicError() string
+	String() string
+	Json() map[string]interface{}
+	TreeMap() map[string]interface{}
+	Response() ErrorResponse
+	EncodeJSON(w io.Writer) error
+	Debug() string
+	Pretty(color bool) string
+	Code() string
+	Message() string
+	StatusCode() int
+	Fields() map[string]interface{}
+	Unwrap() []error
+	Is(target error) bool
+}
+
+// view wraps the error instead of exposing it, so that it cannot be asserted back to ErrorWrapper.
+type view struct {
+	e *wrapper
+}
+
+// View returns a read-only view of the error. The view reflects later changes made to the error.
+func (e *wrapper) View() ErrorView {
+	return view{e: e}
+}
+
+func (v view) Error() string                   { return v.e.Error() }
+func (v view) PublicError() string             { return v.e.PublicError() }
+func (v view) String() string                  { return v.e.String() }
+func (v view) Json() map[string]interface{}    { return v.e.Json() }
+func (v view) TreeMap() map[string]interface{} { return v.e.TreeMap() }
+func (v view) Response() ErrorResponse         { return v.e.Response() }
+func (v view) EncodeJSON(w io.Writer) error    { return v.e.EncodeJSON(w) }
+func (v view) Debug() string                   { return v.e.Debug() }
+func (v view) Pretty(color bool) string        { return v.e.Pretty(color) }
+func (v view) Code() string                    { return Code(v.e) }
+func (v view) Message() string                 { return Message(v.e) }
+func (v view) StatusCode() int                 { return GetStatusCode(v.e) }
+func (v view) Fields() map[string]interface{}  { return Fields(v.e) }
+func (v view) Unwrap() []error                 { return v.e.Unwrap() }
+func (v view) Is(target error) bool            { return v.e.Is(target) }
@@ -0,0 +1,69 @@
+// Package wrapperrorstest provides test assertions for errors created by wrapperrors, so that tests can
+// check codes and statuses instead of comparing Error strings.
+package wrapperrorstest
+
+import (
+	"errors"
+
+	"github.com/felipewom/go-wrapperrors/wrapperrors"
+)
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertCode checks that the given error carries the given code, and reports the codes it carries
+// otherwise.
+func AssertCode(t TestingT, err error, code string) bool {
+	t.Helper()
+	if wrapperrors.HasCode(err, code) {
+		return true
+	}
+	t.Errorf("expected error with code %q, got %s", code, describe(err))
+	return false
+}
+
+// AssertStatus checks that the status code of the given error, as returned by GetStatusCode, is the
+// given one.
+func AssertStatus(t TestingT, err error, status int) bool {
+	t.Helper()
+	got := wrapperrors.GetStatusCode(err)
+	if got == status {
+		return true
+	}
+	t.Errorf("expected error with status %d, got status %d from %s", status, got, describe(err))
+	return false
+}
+
+// AssertIs checks that the given error or an error in its chain matches the given definition, as
+// reported by errors.Is.
+func AssertIs(t TestingT, err error, definition error) bool {
+	t.Helper()
+	if errors.Is(err, definition) {
+		return true
+	}
+	t.Errorf("expected error matching %s, got %s", describe(definition), describe(err))
+	return false
+}
+
+// AssertEqual checks that both errors are equal, as reported by ErrorWrapper.Equal.
+func AssertEqual(t TestingT, expected wrapperrors.ErrorWrapper, actual error) bool {
+	t.Helper()
+	if wrapper, ok := actual.(wrapperrors.ErrorWrapper); ok && expected.Equal(wrapper) {
+		return true
+	}
+	t.Errorf("expected error equal to %s, got %s", describe(expected), describe(actual))
+	return false
+}
+
+func describe(err error) string {
+	if err == nil {
+		return "nil error"
+	}
+	if wrapper, ok := err.(wrapperrors.ErrorWrapper); ok {
+		return wrapper.String()
+	}
+	return "non-wrapper error " + err.Error()
+}
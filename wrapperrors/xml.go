@@ -0,0 +1,44 @@
+package wrapperrors
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// MarshalXML encodes the error as an <error> element holding one <code> and <message> element per
+// entry, one <status> element per status and one <cause> element per cause.
+func (e *wrapper) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "error"}
+	start.Attr = nil
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if e != nil {
+		defer e.rlock()()
+		for _, code := range e.code {
+			if err := enc.EncodeElement(code, xml.StartElement{Name: xml.Name{Local: "code"}}); err != nil {
+				return err
+			}
+		}
+		for _, message := range e.message {
+			if err := enc.EncodeElement(message, xml.StartElement{Name: xml.Name{Local: "message"}}); err != nil {
+				return err
+			}
+		}
+		for _, status := range e.status {
+			statusStart := xml.StartElement{
+				Name: xml.Name{Local: "status"},
+				Attr: []xml.Attr{{Name: xml.Name{Local: "code"}, Value: strconv.Itoa(status.code)}},
+			}
+			if err := enc.EncodeElement(status.message, statusStart); err != nil {
+				return err
+			}
+		}
+		for _, cause := range e.causes {
+			if err := enc.EncodeElement(cause.Error(), xml.StartElement{Name: xml.Name{Local: "cause"}}); err != nil {
+				return err
+			}
+		}
+	}
+	return enc.EncodeToken(start.End())
+}